@@ -13,7 +13,7 @@ func (s *Session) DismissAlert() *Session {
 		http.MethodPost, fmt.Sprintf("/session/%s/alert/dismiss", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 
 		return s
 	}
@@ -27,7 +27,7 @@ func (s *Session) AcceptAlert() *Session {
 		http.MethodPost, fmt.Sprintf("/session/%s/alert/accept", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 
 		return s
 	}
@@ -41,13 +41,13 @@ func (s *Session) GetAlertText() string {
 		http.MethodGet, fmt.Sprintf("/session/%s/alert/text", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 
 		return ""
 	}
 
 	if res.Value == nil {
-		handleError(nil, errors.New("failed to get alert text"))
+		s.handleError(nil, errors.New("failed to get alert text"))
 	}
 
 	if v, ok := res.Value.(string); ok {
@@ -69,7 +69,7 @@ func (s *Session) SendAlertText(text string) *Session {
 		http.MethodPost, fmt.Sprintf("/session/%s/alert/text", s.id), payload,
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 
 		return s
 	}
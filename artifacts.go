@@ -0,0 +1,313 @@
+package selenium
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+	"github.com/pkg/errors"
+)
+
+// Artifacts that can be set in runner.artifacts.collect.
+const (
+	screenshotArtifact    = "screenshot"
+	pageSourceArtifact    = "page_source"
+	urlArtifact           = "url"
+	titleArtifact         = "title"
+	windowHandlesArtifact = "window_handles"
+	consoleLogsArtifact   = "console_logs"
+)
+
+var artifactKinds = []string{
+	screenshotArtifact,
+	pageSourceArtifact,
+	urlArtifact,
+	titleArtifact,
+	windowHandlesArtifact,
+	consoleLogsArtifact,
+}
+
+// Files that are created in the artifact directory.
+const (
+	artifactsInfoFile  = "artifacts.json"
+	screenshotFile     = "screenshot.png"
+	pageSourceFile     = "page.html"
+	consoleLogsFile    = "console.log"
+	defaultArtifactDir = "artifacts"
+)
+
+// Artifacts describe the state of the browser when the test failed. They are
+// collected before the session is deleted if runner.artifacts is set. Paths are
+// empty if the artifact is not collected or not available, e.g., console logs
+// are only provided by Chrome.
+type Artifacts struct {
+	// Dir is the directory of the test's attempt that contains the files.
+	Dir           string   `json:"dir"`
+	Screenshot    string   `json:"screenshot,omitempty"`
+	PageSource    string   `json:"page_source,omitempty"`
+	ConsoleLogs   string   `json:"console_logs,omitempty"`
+	URL           string   `json:"url,omitempty"`
+	Title         string   `json:"title,omitempty"`
+	WindowHandles []string `json:"window_handles,omitempty"`
+}
+
+// files returns paths of the collected files, including the file that
+// describes the artifacts. Screenshot is not included, as it is reported along
+// with other screenshots of the attempt.
+func (a *Artifacts) files() []string {
+	files := []string{filepath.Join(a.Dir, artifactsInfoFile)}
+
+	for _, file := range []string{a.PageSource, a.ConsoleLogs} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+// consoleLogEntry is a browser log entry returned by the legacy log endpoint.
+type consoleLogEntry struct {
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// collectArtifacts saves artifacts set in runner.artifacts.collect to the test
+// attempt's directory. Nil is returned if artifacts are not configured or the
+// directory cannot be created. Artifacts that cannot be collected are skipped.
+func collectArtifacts(s *Session) *Artifacts {
+	if config == nil || config.Runner == nil || config.Runner.Artifacts == nil {
+		return nil
+	}
+
+	settings := config.Runner.Artifacts
+
+	dir, err := createArtifactDir(settings.Dir, s)
+	if err != nil {
+		logger.Errorf("Failed to collect failure artifacts: %s", err)
+
+		return nil
+	}
+
+	a := &Artifacts{Dir: dir}
+
+	for _, kind := range settings.Collect {
+		err := a.collect(s, kind)
+		if err != nil {
+			logger.Warnf("Failed to collect %s: %s", kind, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err == nil {
+		//nolint:gosec
+		err = os.WriteFile(filepath.Join(dir, artifactsInfoFile), data, 0644)
+	}
+
+	if err != nil {
+		logger.Errorf("Failed to write %s: %s", artifactsInfoFile, err)
+	}
+
+	return a
+}
+
+// saveFailureArtifacts collects artifacts via conn, which is either the session
+// or its detached copy if the session is cancelled, and sets them for the
+// session.
+func (s *Session) saveFailureArtifacts(conn *Session) *Artifacts {
+	a := collectArtifacts(conn)
+	if a == nil {
+		return nil
+	}
+
+	s.setArtifacts(a)
+	s.infof("Failure artifacts are saved to %s", a.Dir)
+
+	return a
+}
+
+func (a *Artifacts) collect(s *Session, kind string) error {
+	switch kind {
+	case screenshotArtifact:
+		data, err := getSessionValue[string](s, http.MethodGet, "/screenshot")
+		if err != nil {
+			return err
+		}
+
+		img, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return errors.Wrap(err, "failed to decode base64")
+		}
+
+		a.Screenshot, err = a.writeFile(screenshotFile, img)
+
+		return err
+	case pageSourceArtifact:
+		source, err := getSessionValue[string](s, http.MethodGet, "/source")
+		if err != nil {
+			return err
+		}
+
+		a.PageSource, err = a.writeFile(pageSourceFile, []byte(source))
+
+		return err
+	case urlArtifact:
+		url, err := getSessionValue[string](s, http.MethodGet, "/url")
+		a.URL = url
+
+		return err
+	case titleArtifact:
+		title, err := getSessionValue[string](s, http.MethodGet, "/title")
+		a.Title = title
+
+		return err
+	case windowHandlesArtifact:
+		handles, err := getSessionValue[[]string](
+			s, http.MethodGet, "/window/handles",
+		)
+		a.WindowHandles = handles
+
+		return err
+	case consoleLogsArtifact:
+		return a.collectConsoleLogs(s)
+	default:
+		return errors.Errorf("unknown artifact %q", kind)
+	}
+}
+
+// collectConsoleLogs saves browser's console logs. Logs are only available via
+// the legacy log endpoint that is supported by chromedriver, therefore, the
+// artifact is silently skipped for other drivers.
+func (a *Artifacts) collectConsoleLogs(s *Session) error {
+	payload := struct {
+		Type string `json:"type"`
+	}{"browser"}
+
+	entries, err := getSessionValue[[]consoleLogEntry](
+		s, http.MethodPost, "/se/log", payload,
+	)
+	if err != nil {
+		//nolint:nilerr
+		return nil
+	}
+
+	var sb strings.Builder
+
+	for _, e := range entries {
+		fmt.Fprintf(
+			&sb, "%s [%s] %s\n",
+			time.UnixMilli(e.Timestamp).Format("15:04:05.000"),
+			e.Level, e.Message,
+		)
+	}
+
+	a.ConsoleLogs, err = a.writeFile(consoleLogsFile, []byte(sb.String()))
+
+	return err
+}
+
+func (a *Artifacts) writeFile(name string, data []byte) (string, error) {
+	file := filepath.Join(a.Dir, name)
+
+	//nolint:gosec
+	err := os.WriteFile(file, data, 0644)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to write %s", name)
+	}
+
+	return file, nil
+}
+
+// getSessionValue sends the session's command and returns the value of the
+// response. Unlike the session's actions, errors are returned instead of being
+// handled, so that the artifacts can be collected in the hard assertion mode.
+func getSessionValue[T any](
+	s *Session, method, route string, payload ...interface{},
+) (T, error) {
+	var response struct {
+		Value T `json:"value"`
+	}
+
+	var body interface{} = struct{}{}
+	if len(payload) > 0 {
+		body = payload[0]
+	}
+
+	_, err := s.api.executeRequestCustom(
+		method, fmt.Sprintf("/session/%s%s", s.id, route), body, &response,
+	)
+
+	return response.Value, err
+}
+
+var unsafePathChars = regexp.MustCompile(`[^\w.-]+`)
+
+// artifactRun is the directory of the current run, so that artifacts of the
+// previous runs are not overwritten.
+var artifactRun = "run-" + time.Now().Format("20060102-150405")
+
+// createArtifactDir creates an empty <dir>/<run>/<test>/attempt-<n> directory.
+// Files that are left in the directory, e.g., by the run that started within
+// the same second, are removed.
+func createArtifactDir(dir string, s *Session) (string, error) {
+	name := artifactDirName(s.test)
+	if name == "" {
+		name = "session-" + s.id
+	}
+
+	dir = filepath.Join(
+		dir, artifactRun, name, fmt.Sprintf("attempt-%d", s.attempt),
+	)
+
+	err := os.RemoveAll(dir)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to clear artifact directory")
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create artifact directory")
+	}
+
+	return dir, nil
+}
+
+// artifactDirName returns the test's name that is safe to use as a directory
+// name. If unsafe characters are replaced, the hash of the name is appended,
+// so that, e.g., "a/b" and "a_b" do not share the directory.
+func artifactDirName(test string) string {
+	name := unsafePathChars.ReplaceAllString(test, "_")
+	if name == test {
+		return name
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(test))
+
+	return fmt.Sprintf("%s-%08x", name, h.Sum32())
+}
+
+// setArtifacts sets artifacts collected for the session.
+func (s *Session) setArtifacts(a *Artifacts) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.artifacts = a
+}
+
+// getArtifacts returns artifacts collected for the session.
+func (s *Session) getArtifacts() *Artifacts {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.artifacts
+}
@@ -0,0 +1,126 @@
+package selenium
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestArtifactDirName(t *testing.T) {
+	names := map[string]bool{}
+
+	for _, test := range []string{"a_b", "a/b", "a b", "a:b"} {
+		name := artifactDirName(test)
+
+		if unsafePathChars.MatchString(name) {
+			t.Errorf("%q has unsafe characters", name)
+		}
+
+		if names[name] {
+			t.Errorf("%q is used by several tests", name)
+		}
+
+		names[name] = true
+	}
+
+	if name := artifactDirName("checkout-1.2"); name != "checkout-1.2" {
+		t.Errorf("safe name is changed to %q", name)
+	}
+}
+
+func TestCreateArtifactDir(t *testing.T) {
+	root := t.TempDir()
+	s := &Session{id: "fake", test: "checkout", attempt: 2}
+
+	dir, err := createArtifactDir(root, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := filepath.Join(root, artifactRun, "checkout", "attempt-2")
+	if dir != want {
+		t.Errorf("expected %s, got %s", want, dir)
+	}
+
+	stale := filepath.Join(dir, screenshotFile)
+
+	err = os.WriteFile(stale, []byte("stale"), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = createArtifactDir(root, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("stale artifact is not removed: %v", err)
+	}
+
+	dir, err = createArtifactDir(root, &Session{id: "fake", attempt: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if filepath.Base(filepath.Dir(dir)) != "session-fake" {
+		t.Errorf("session's ID is not used for the unnamed test: %s", dir)
+	}
+}
+
+func TestArtifactSettingsValidate(t *testing.T) {
+	a := &artifactSettings{}
+	a.validate()
+
+	if a.Dir != defaultArtifactDir ||
+		!reflect.DeepEqual(a.Collect, artifactKinds) {
+		t.Errorf("unexpected defaults %+v", a)
+	}
+
+	a = &artifactSettings{
+		Dir:     "out",
+		Collect: []string{"url", "video", "screenshot"},
+	}
+	a.validate()
+
+	if a.Dir != "out" ||
+		!reflect.DeepEqual(a.Collect, []string{"url", "screenshot"}) {
+		t.Errorf("unknown artifacts are not removed: %+v", a)
+	}
+}
+
+func TestCollectArtifacts(t *testing.T) {
+	setTestConfig(t).Runner.Artifacts = &artifactSettings{
+		Dir:     t.TempDir(),
+		Collect: []string{urlArtifact, screenshotArtifact, titleArtifact},
+	}
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"GET /session/fake/url": {value: "https://example.com"},
+		"GET /session/fake/screenshot": {
+			value: base64.StdEncoding.EncodeToString([]byte("png")),
+		},
+	})
+	s.test = "checkout"
+	s.attempt = 1
+
+	a := collectArtifacts(s)
+	if a == nil {
+		t.Fatal("artifacts are not collected")
+	}
+
+	if a.URL != "https://example.com" || a.Title != "" {
+		t.Errorf("unexpected artifacts %+v", a)
+	}
+
+	data, err := os.ReadFile(a.Screenshot)
+	if err != nil || string(data) != "png" {
+		t.Errorf("screenshot is not saved: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(a.Dir, artifactsInfoFile)); err != nil {
+		t.Errorf("%s is not written: %v", artifactsInfoFile, err)
+	}
+}
@@ -0,0 +1,204 @@
+package selenium
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+)
+
+// AssertionFailure describes a failed assertion or an error that occurred
+// during the test.
+type AssertionFailure struct {
+	// Message is the full description of the failure.
+	Message string `json:"message"`
+	// Selector of the asserted element. Empty for page assertions.
+	Selector string `json:"selector,omitempty"`
+	// Property is the asserted value, e.g., text or attribute "href".
+	Property string `json:"property,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	// File and Line point to the test code that made the assertion.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+	// Step is the path of the step that failed, e.g., "Log in > Submit".
+	Step string `json:"step,omitempty"`
+	// Screenshot is the path of the screenshot taken when the assertion
+	// failed. Screenshots are taken if screenshot_on_failure is set.
+	Screenshot string    `json:"screenshot,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// packagePath is used to skip the library's frames when looking for the
+// assertion's caller.
+var packagePath = reflect.TypeOf(Session{}).PkgPath()
+
+func (f *AssertionFailure) Error() string {
+	return f.Message
+}
+
+// Location returns the assertion's caller in file:line format. Empty string is
+// returned if the caller is unknown.
+func (f *AssertionFailure) Location() string {
+	if f.File == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%d", f.File, f.Line)
+}
+
+// String returns the message prefixed with the location and followed by the
+// step.
+func (f *AssertionFailure) String() string {
+	msg := f.Message
+	if f.Step != "" {
+		msg = fmt.Sprintf("%s (step %q)", msg, f.Step)
+	}
+
+	if f.File == "" {
+		return msg
+	}
+
+	return fmt.Sprintf("%s: %s", f.Location(), msg)
+}
+
+// newFailure returns a failure with the given message that is located at the
+// current caller.
+func newFailure(message string) *AssertionFailure {
+	f := &AssertionFailure{
+		Message: message,
+		Time:    time.Now(),
+	}
+
+	f.File, f.Line = callerLocation()
+
+	return f
+}
+
+// callerLocation returns the first caller that is not a part of the library
+// or the runtime, i.e., the test code.
+func callerLocation() (string, int) {
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(2, pc)
+	frames := runtime.CallersFrames(pc[:n])
+
+	for {
+		frame, more := frames.Next()
+
+		if !isLibraryFrame(frame.Function) {
+			return frame.File, frame.Line
+		}
+
+		if !more {
+			return "", 0
+		}
+	}
+}
+
+func isLibraryFrame(function string) bool {
+	return strings.HasPrefix(function, packagePath+".") ||
+		strings.HasPrefix(function, packagePath+"/") ||
+		strings.HasPrefix(function, "runtime.")
+}
+
+// fail records the failure. If soft assertions are used, the failure is added
+// to the session's failures, otherwise, it is raised.
+func (s *Session) fail(f *AssertionFailure) {
+	if f.Time.IsZero() {
+		f.Time = time.Now()
+	}
+
+	if f.File == "" {
+		f.File, f.Line = callerLocation()
+	}
+
+	if f.Step == "" {
+		f.Step = s.stepPath()
+	}
+
+	if config.ScreenshotOnFailure {
+		f.Screenshot = s.failureScreenshot()
+	}
+
+	s.errorf("%s", f.String())
+
+	s.addStep(&StepResult{
+		Name:    f.Message,
+		Status:  StatusFailed,
+		Start:   f.Time,
+		Failure: f,
+	})
+
+	s.emit(&Event{
+		Type:       AssertionEvent,
+		Status:     StatusFailed,
+		Message:    f.Message,
+		Screenshot: f.Screenshot,
+		Failure:    f,
+	})
+
+	if config.SoftAsserts {
+		s.addFailure(f)
+
+		return
+	}
+
+	panic(f)
+}
+
+// pass logs the passed assertion.
+func (s *Session) pass(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+
+	s.infof("%s", msg)
+
+	s.addStep(&StepResult{
+		Name:   msg,
+		Status: StatusPassed,
+		Start:  time.Now(),
+	})
+
+	s.emit(&Event{
+		Type:    AssertionEvent,
+		Status:  StatusPassed,
+		Message: msg,
+	})
+}
+
+func (s *Session) addFailure(f *AssertionFailure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors = append(s.errors, f)
+}
+
+// failureScreenshot takes a screenshot and returns its path. Empty string is
+// returned if the screenshot cannot be taken, e.g., the session is closed.
+func (s *Session) failureScreenshot() string {
+	name := fmt.Sprintf("failure_%s_%d.png", s.id, time.Now().UnixNano())
+
+	_, err := callCondition(s.checking(func() (bool, error) {
+		s.TakeScreenshot(name)
+
+		return true, nil
+	}))
+	if err != nil {
+		logger.Errorf("Failed to take screenshot on failure: %s", err)
+
+		return ""
+	}
+
+	file := path.Join(config.ScreenshotDir, name)
+
+	// Errors are only logged when soft assertions are used.
+	if _, err := os.Stat(file); err != nil {
+		return ""
+	}
+
+	return file
+}
@@ -0,0 +1,50 @@
+package selenium
+
+import (
+	"sync"
+	"time"
+)
+
+// webDriverCommand is a request that is sent to the browser driver during the
+// session. Commands are shown in the HTML report's timeline.
+type webDriverCommand struct {
+	start    time.Time
+	method   string
+	route    string
+	status   int
+	duration time.Duration
+	err      error
+}
+
+// commandLog records the session's commands.
+type commandLog struct {
+	mu       sync.Mutex
+	commands []webDriverCommand
+}
+
+// record adds the command to the log. Nil log does not record commands.
+func (l *commandLog) record(c webDriverCommand) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.commands = append(l.commands, c)
+}
+
+// entries returns the recorded commands.
+func (l *commandLog) entries() []webDriverCommand {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	commands := make([]webDriverCommand, len(l.commands))
+	copy(commands, l.commands)
+
+	return commands
+}
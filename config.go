@@ -12,8 +12,27 @@ import (
 	"github.com/pkg/errors"
 )
 
+type reportSettings struct {
+	Format string `json:"format"`
+	Output string `json:"output,omitempty"`
+}
+
+type artifactSettings struct {
+	Dir     string   `json:"dir,omitempty"`
+	Collect []string `json:"collect,omitempty"`
+}
+
 type runnerSettings struct {
-	ParallelRuns int `json:"parallel_runs"`
+	ParallelRuns int              `json:"parallel_runs"`
+	Tags         []string         `json:"tags,omitempty"`
+	ExcludeTags  []string         `json:"exclude_tags,omitempty"`
+	Names        []string         `json:"names,omitempty"`
+	Retries      int              `json:"retries,omitempty"`
+	Timeout      types.Time       `json:"timeout"`
+	TestTimeout  types.Time       `json:"test_timeout"`
+	Reports      []reportSettings `json:"reports,omitempty"`
+	// Artifacts are collected when the test fails if they are set.
+	Artifacts *artifactSettings `json:"artifacts,omitempty"`
 }
 
 type elementSettings struct {
@@ -28,6 +47,7 @@ type webDriverConfig struct {
 	ManualStart  bool                   `json:"manual_start,omitempty"`
 	BinaryPath   string                 `json:"binary_path,omitempty"`
 	RemoteURL    string                 `json:"remote_url,omitempty"`
+	Remote       bool                   `json:"remote,omitempty"`
 	Timeout      *types.Time            `json:"timeout,omitempty"`
 	Capabalities map[string]interface{} `json:"capabilities,omitempty"`
 }
@@ -36,6 +56,8 @@ type configParams struct {
 	LogLevel            string           `json:"logging"`
 	SoftAsserts         bool             `json:"soft_asserts"`
 	ScreenshotDir       string           `json:"screenshot_dir,omitempty"`
+	ScreenshotOnFailure bool             `json:"screenshot_on_failure,omitempty"` //nolint:lll
+	DownloadDir         string           `json:"download_dir,omitempty"`
 	RaiseErrorsManually bool             `json:"raise_errors_automatically,omitempty"` //nolint:lll
 	Runner              *runnerSettings  `json:"runner,omitempty"`
 	Element             *elementSettings `json:"element,omitempty"`
@@ -163,6 +185,48 @@ func (c *configParams) validateRunner() {
 
 		c.Runner.ParallelRuns = 1
 	}
+
+	if c.Runner.Retries < 0 {
+		logger.Warn(`"retries" is less than 0. Setting it to 0.`)
+
+		c.Runner.Retries = 0
+	}
+
+	if c.Runner.Artifacts != nil {
+		c.Runner.Artifacts.validate()
+	}
+}
+
+func (a *artifactSettings) validate() {
+	if a.Dir == "" {
+		a.Dir = defaultArtifactDir
+	}
+
+	if len(a.Collect) == 0 {
+		a.Collect = artifactKinds
+
+		return
+	}
+
+	known := make(map[string]bool, len(artifactKinds))
+
+	for _, kind := range artifactKinds {
+		known[kind] = true
+	}
+
+	collect := make([]string, 0, len(a.Collect))
+
+	for _, kind := range a.Collect {
+		if !known[kind] {
+			logger.Warnf("Unknown artifact %q is not collected.", kind)
+
+			continue
+		}
+
+		collect = append(collect, kind)
+	}
+
+	a.Collect = collect
 }
 
 func (c *configParams) validateElement() {
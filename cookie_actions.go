@@ -0,0 +1,53 @@
+package selenium
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Cookie describes a cookie visible to the current page.
+type Cookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Expiry   int64  `json:"expiry,omitempty"`
+	SameSite string `json:"sameSite,omitempty"`
+}
+
+// GetCookies returns all cookies visible to the current page.
+func (s *Session) GetCookies() []Cookie {
+	var response struct {
+		Value []Cookie `json:"value"`
+	}
+
+	res, err := s.api.executeRequestCustom(
+		http.MethodGet,
+		fmt.Sprintf("/session/%s/cookie", s.id),
+		struct{}{},
+		&response,
+	)
+	if err != nil {
+		s.handleError(res, err)
+
+		return []Cookie{}
+	}
+
+	return response.Value
+}
+
+// GetCookie returns the cookie with the given name. Nil is returned if the
+// cookie is not set.
+func (s *Session) GetCookie(name string) *Cookie {
+	for _, c := range s.GetCookies() {
+		if c.Name == name {
+			c := c
+
+			return &c
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package selenium
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -13,13 +14,13 @@ func (s *Session) GetPageSoure() string {
 		http.MethodGet, fmt.Sprintf("/session/%s/source", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 
 		return ""
 	}
 
 	if res.Value == nil {
-		handleError(nil, errors.New("failed to get page source"))
+		s.handleError(nil, errors.New("failed to get page source"))
 
 		return ""
 	}
@@ -31,32 +32,215 @@ func (s *Session) GetPageSoure() string {
 	return ""
 }
 
+type scriptMode string
+
+const (
+	syncScript  scriptMode = "sync"
+	asyncScript scriptMode = "async"
+)
+
 // ExecuteScript executes user defined JavaScript function written as a string.
-// ...args are passed to the function as arguments. Function's return value is
-// returned as interface{}.
-func (s *Session) ExecuteScript(script string, args ...string) interface{} {
-	if len(args) == 0 {
-		args = []string{}
+// ...args are passed to the function as arguments. Arguments can be of any
+// JSON type, *Element, []*Element or *Elements. Function's return value is
+// returned as interface{}. Returned web elements are converted to *Element.
+func (s *Session) ExecuteScript(
+	script string, args ...interface{},
+) interface{} {
+	return s.parseScriptResult(s.executeScript(syncScript, script, args))
+}
+
+// ExecuteAsyncScript executes user defined asynchronous JavaScript function
+// written as a string. The function receives ...args followed by a callback
+// that must be called with the function's result. Arguments and the return
+// value are handled the same way as in ExecuteScript.
+func (s *Session) ExecuteAsyncScript(
+	script string, args ...interface{},
+) interface{} {
+	return s.parseScriptResult(s.executeScript(asyncScript, script, args))
+}
+
+// ExecuteScriptInto executes user defined JavaScript function the same way as
+// Session.ExecuteScript and unmarshals function's return value into T.
+func ExecuteScriptInto[T any](
+	s *Session, script string, args ...interface{},
+) T {
+	var result T
+
+	raw := s.executeScript(syncScript, script, args)
+	if raw == nil {
+		return result
 	}
 
-	script = fmt.Sprintf("return (%s).apply(window, arguments)", script)
+	// Web elements cannot be unmarshalled without the session they belong to.
+	switch v := any(&result).(type) {
+	case **Element:
+		*v, _ = s.parseScriptResult(raw).(*Element)
+
+		return result
+	case *[]*Element:
+		values, _ := s.parseScriptResult(raw).([]interface{})
+
+		for _, value := range values {
+			if e, ok := value.(*Element); ok {
+				*v = append(*v, e)
+			}
+		}
+
+		return result
+	}
+
+	err := json.Unmarshal(raw, &result)
+	if err != nil {
+		s.handleError(
+			nil,
+			errors.Wrapf(
+				err, "failed to unmarshal script result into %T", result,
+			),
+		)
+	}
 
+	return result
+}
+
+func (s *Session) executeScript(
+	mode scriptMode, script string, args []interface{},
+) json.RawMessage {
 	payload := struct {
-		Script string   `json:"script"`
-		Args   []string `json:"args"`
+		Script string        `json:"script"`
+		Args   []interface{} `json:"args"`
 	}{
-		Script: script,
-		Args:   args,
+		Script: fmt.Sprintf("return (%s).apply(window, arguments)", script),
+		Args:   make([]interface{}, 0, len(args)),
+	}
+
+	for _, arg := range args {
+		payload.Args = append(payload.Args, scriptArgument(arg))
 	}
 
-	res, err := s.api.executeRequest(
-		http.MethodPost, fmt.Sprintf("/session/%s/execute/sync", s.id), payload,
+	data, err := s.api.executeRequestRaw(
+		http.MethodPost,
+		fmt.Sprintf("/session/%s/execute/%s", s.id, mode),
+		payload,
 	)
 	if err != nil {
-		handleError(res, err)
+		var res *response
+
+		//nolint:errcheck
+		json.Unmarshal(data, &res)
+
+		s.handleError(res, err)
+
+		return nil
+	}
+
+	var res struct {
+		Value json.RawMessage `json:"value"`
+	}
+
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		s.handleError(
+			nil, errors.Wrap(err, "failed to unmarshal script result"),
+		)
 
-		return s
+		return nil
 	}
 
 	return res.Value
 }
+
+// scriptArgument converts web elements to references that can be sent to the
+// browser driver. Other values are returned as is.
+func scriptArgument(arg interface{}) interface{} {
+	switch v := arg.(type) {
+	case *Element:
+		return v.reference()
+	case []*Element:
+		refs := make([]elementReference, 0, len(v))
+
+		for _, e := range v {
+			refs = append(refs, e.reference())
+		}
+
+		return refs
+	case *Elements:
+		return scriptArgument(v.Elements())
+	case []interface{}:
+		values := make([]interface{}, 0, len(v))
+
+		for _, value := range v {
+			values = append(values, scriptArgument(value))
+		}
+
+		return values
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(v))
+
+		for k, value := range v {
+			values[k] = scriptArgument(value)
+		}
+
+		return values
+	default:
+		return v
+	}
+}
+
+func (s *Session) parseScriptResult(raw json.RawMessage) interface{} {
+	if raw == nil {
+		return nil
+	}
+
+	var value interface{}
+
+	err := json.Unmarshal(raw, &value)
+	if err != nil {
+		s.handleError(
+			nil, errors.Wrap(err, "failed to unmarshal script result"),
+		)
+
+		return nil
+	}
+
+	return s.convertScriptValue(value)
+}
+
+// newScriptElement returns the element with the given ID that is returned by a
+// script. The selector only describes the element in logs and failures.
+func (s *Session) newScriptElement(id string) *Element {
+	return &Element{
+		E: E{
+			Selector: fmt.Sprintf("<element %s returned by script>", id),
+		},
+		id:       id,
+		scripted: true,
+		settings: config.Element,
+		session:  s,
+		api:      s.api,
+	}
+}
+
+// convertScriptValue replaces web element references found in the script's
+// return value with *Element.
+func (s *Session) convertScriptValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			v[i] = s.convertScriptValue(item)
+		}
+
+		return v
+	case map[string]interface{}:
+		if id := getElementID(v); id != "" && len(v) == 1 {
+			return s.newScriptElement(id)
+		}
+
+		for k, item := range v {
+			v[k] = s.convertScriptValue(item)
+		}
+
+		return v
+	default:
+		return v
+	}
+}
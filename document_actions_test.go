@@ -0,0 +1,159 @@
+package selenium
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScriptArgument(t *testing.T) {
+	a := &Element{id: "a"}
+	b := &Element{id: "b"}
+
+	got := scriptArgument([]interface{}{
+		a,
+		"text",
+		map[string]interface{}{
+			"elements": []*Element{a, b},
+			"nested":   []interface{}{b, 1},
+		},
+	})
+
+	want := []interface{}{
+		elementReference{"a"},
+		"text",
+		map[string]interface{}{
+			"elements": []elementReference{{"a"}, {"b"}},
+			"nested":   []interface{}{elementReference{"b"}, 1},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("scriptArgument() = %#v, want %#v", got, want)
+	}
+}
+
+func TestConvertScriptValue(t *testing.T) {
+	setTestConfig(t)
+
+	s := newFakeSession(t, nil)
+
+	value := s.convertScriptValue(map[string]interface{}{
+		"title": "Checkout",
+		"items": []interface{}{
+			map[string]interface{}{webElementID: "a"},
+			map[string]interface{}{legacyElementID: "b"},
+			map[string]interface{}{webElementID: "c", "other": true},
+		},
+	})
+
+	items := value.(map[string]interface{})["items"].([]interface{})
+
+	for i, id := range []string{"a", "b"} {
+		e, ok := items[i].(*Element)
+		if !ok {
+			t.Fatalf("item %d is not converted to *Element: %#v", i, items[i])
+		}
+
+		checkScriptElement(t, e, id)
+	}
+
+	if _, ok := items[2].(map[string]interface{}); !ok {
+		t.Errorf("object with other keys is converted: %#v", items[2])
+	}
+}
+
+func TestExecuteScriptInto(t *testing.T) {
+	setTestConfig(t)
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/execute/sync": {
+			value: []interface{}{
+				map[string]string{webElementID: "a"},
+				map[string]string{webElementID: "b"},
+			},
+		},
+	})
+
+	elements := ExecuteScriptInto[[]*Element](s, "function () {}")
+	if len(elements) != 2 {
+		t.Fatalf("expected 2 elements, got %d", len(elements))
+	}
+
+	checkScriptElement(t, elements[1], "b")
+
+	s = newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/execute/sync": {
+			value: map[string]string{webElementID: "a"},
+		},
+	})
+
+	checkScriptElement(t, ExecuteScriptInto[*Element](s, "function () {}"), "a")
+
+	s = newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/execute/sync": {
+			value: map[string]interface{}{"name": "Checkout", "count": 2},
+		},
+	})
+
+	type result struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	got := ExecuteScriptInto[result](s, "function () {}")
+	if got != (result{Name: "Checkout", Count: 2}) {
+		t.Errorf("unexpected result %+v", got)
+	}
+}
+
+func TestExecuteAsyncScript(t *testing.T) {
+	setTestConfig(t)
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/execute/async": {value: "Checkout"},
+	})
+
+	title := s.ExecuteAsyncScript("function (done) { done(document.title) }")
+	if title != "Checkout" {
+		t.Errorf("unexpected result %v", title)
+	}
+}
+
+func TestScriptElementIsNotRelocated(t *testing.T) {
+	setTestConfig(t)
+
+	s := newFakeSession(t, nil)
+
+	stale := func() (bool, error) {
+		return false, &errorResponse{Err: "stale element reference"}
+	}
+
+	e := s.newScriptElement("a")
+
+	_, _ = e.relocating(stale)()
+
+	if e.id != "a" || len(e.staleErrors()) != 0 {
+		t.Errorf("element returned by script is located again")
+	}
+
+	e = s.NewElement("#a")
+	e.id = "a"
+
+	_, _ = e.relocating(stale)()
+
+	if e.id != "" || len(e.staleErrors()) != 1 {
+		t.Errorf("stale element is not located again")
+	}
+}
+
+func checkScriptElement(t *testing.T, e *Element, id string) {
+	t.Helper()
+
+	if e == nil || e.id != id || !e.scripted {
+		t.Fatalf("expected element %s returned by script, got %+v", id, e)
+	}
+
+	if e.SelectorType != "" || e.Selector == id {
+		t.Errorf("element's ID is used as selector: %+v", e.E)
+	}
+}
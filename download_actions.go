@@ -0,0 +1,246 @@
+package selenium
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+	"github.com/pkg/errors"
+)
+
+// Extensions that browsers append to names of files that are still being
+// downloaded.
+var partialDownloadExtensions = []string{
+	".crdownload", ".part", ".partial", ".download",
+}
+
+// MIME types that Firefox saves without asking.
+var firefoxDownloadMIMETypes = strings.Join([]string{
+	"application/octet-stream",
+	"application/pdf",
+	"application/zip",
+	"application/json",
+	"application/xml",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"text/csv",
+	"text/plain",
+	"text/xml",
+	"image/png",
+	"image/jpeg",
+}, ",")
+
+// GetDownloadDir returns the session's download directory. Empty string is
+// returned if download_dir is not set in the config.
+func (s *Session) GetDownloadDir() string {
+	return s.downloadDir
+}
+
+// WaitForDownload waits until a file whose name matches the given pattern is
+// downloaded to the session's download directory and returns the file's path.
+// Download is considered finished when there are no partially downloaded files
+// matching the pattern left, e.g., "report.csv.crdownload" for "report*.csv".
+// Pattern syntax is described in filepath.Match.
+func (s *Session) WaitForDownload(
+	pattern string, timeout time.Duration,
+) string {
+	if s.downloadDir == "" {
+		s.handleError(
+			nil, errors.New(`"download_dir" is not set in the config`),
+		)
+
+		return ""
+	}
+
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		s.handleError(nil, errors.Wrapf(err, "invalid pattern %q", pattern))
+
+		return ""
+	}
+
+	startTime := time.Now()
+	endTime := startTime.Add(timeout)
+
+	for {
+		if endTime.Before(time.Now()) {
+			s.handleError(
+				nil,
+				errors.Errorf(
+					"File %q is not downloaded after %s (time elapsed %dms)",
+					pattern, timeout, time.Since(startTime).Milliseconds(),
+				),
+			)
+
+			return ""
+		}
+
+		file, err := s.findDownload(pattern)
+		if err != nil {
+			s.handleError(nil, err)
+
+			return ""
+		}
+
+		if file != "" {
+			s.infof(
+				"File %q is downloaded after %s (time elapsed %dms)",
+				filepath.Base(file), timeout,
+				time.Since(startTime).Milliseconds(),
+			)
+
+			return file
+		}
+
+		time.Sleep(config.Element.PollInterval.Duration)
+	}
+}
+
+// findDownload returns the path of the downloaded file matching the pattern.
+// Empty string is returned if the file is not found or if files matching the
+// pattern are still being downloaded.
+func (s *Session) findDownload(pattern string) (string, error) {
+	entries, err := os.ReadDir(s.downloadDir)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read download directory")
+	}
+
+	file := matchDownload(entries, pattern)
+	if file == "" {
+		return "", nil
+	}
+
+	return filepath.Join(s.downloadDir, file), nil
+}
+
+// matchDownload returns the name of the first downloaded file matching the
+// pattern. Empty string is returned if the file is not found or if there are
+// partial downloads of files matching the pattern. Pattern must be valid.
+func matchDownload(entries []os.DirEntry, pattern string) string {
+	var file string
+
+	for _, entry := range entries {
+		// Safari downloads files to .download bundles, therefore, partial
+		// downloads are checked before directories are skipped.
+		if name, ok := partialDownloadName(entry.Name()); ok {
+			if matched, _ := filepath.Match(pattern, name); matched {
+				return ""
+			}
+
+			continue
+		}
+
+		if entry.IsDir() {
+			continue
+		}
+
+		ok, _ := filepath.Match(pattern, entry.Name())
+		if ok && file == "" {
+			file = entry.Name()
+		}
+	}
+
+	return file
+}
+
+// partialDownloadName returns the name of the file that is being downloaded if
+// name is a partial download, e.g., "report.csv" for "report.csv.part".
+func partialDownloadName(name string) (string, bool) {
+	for _, ext := range partialDownloadExtensions {
+		if strings.HasSuffix(name, ext) {
+			return strings.TrimSuffix(name, ext), true
+		}
+	}
+
+	return "", false
+}
+
+// removeDownloadDir removes the session's download directory with all
+// downloaded files.
+func removeDownloadDir(dir string) {
+	if dir == "" {
+		return
+	}
+
+	err := os.RemoveAll(dir)
+	if err != nil {
+		logger.Errorf("Failed to remove download directory: %s", err)
+	}
+}
+
+// createDownloadDir creates a unique download directory for a new session
+// within download_dir. If download_dir is not set, no directory is created.
+func createDownloadDir() (string, error) {
+	if config.DownloadDir == "" {
+		return "", nil
+	}
+
+	err := os.MkdirAll(config.DownloadDir, 0755)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create download_dir")
+	}
+
+	dir, err := os.MkdirTemp(config.DownloadDir, "session-")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create session directory")
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get absolute path")
+	}
+
+	return abs, nil
+}
+
+// setDownloadDir sets browser preferences in capabilities so that files are
+// downloaded to the given directory without prompting.
+func setDownloadDir(caps map[string]interface{}, dir string) {
+	switch parseDriver(config.WebDriver.Browser) {
+	case chromedriver:
+		opts := copyCapability(caps["goog:chromeOptions"])
+		prefs := copyCapability(opts["prefs"])
+
+		prefs["download.default_directory"] = dir
+		prefs["download.prompt_for_download"] = false
+		prefs["download.directory_upgrade"] = true
+
+		opts["prefs"] = prefs
+		caps["goog:chromeOptions"] = opts
+	case geckodriver:
+		opts := copyCapability(caps["moz:firefoxOptions"])
+		prefs := copyCapability(opts["prefs"])
+
+		prefs["browser.download.dir"] = dir
+		prefs["browser.download.folderList"] = 2
+		prefs["browser.download.useDownloadDir"] = true
+		prefs["browser.download.manager.showWhenStarting"] = false
+		prefs["browser.helperApps.neverAsk.saveToDisk"] =
+			firefoxDownloadMIMETypes
+		prefs["pdfjs.disabled"] = true
+
+		opts["prefs"] = prefs
+		caps["moz:firefoxOptions"] = opts
+	default:
+		logger.Warnf(
+			"Download directory is not supported for %q browser",
+			config.WebDriver.Browser,
+		)
+	}
+}
+
+// copyCapability returns a shallow copy of the capability object, so that
+// config values are not modified. If v is not an object, an empty one is
+// returned.
+func copyCapability(v interface{}) map[string]interface{} {
+	c := make(map[string]interface{})
+
+	if m, ok := v.(map[string]interface{}); ok {
+		for k, value := range m {
+			c[k] = value
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,78 @@
+package selenium
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindDownload(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		pattern string
+		want    string
+	}{
+		{
+			name:    "finished download",
+			files:   []string{"report.csv"},
+			pattern: "report*.csv",
+			want:    "report.csv",
+		},
+		{
+			name:    "no matching file",
+			files:   []string{"invoice.pdf"},
+			pattern: "report*.csv",
+		},
+		{
+			name:    "partial download of matching file",
+			files:   []string{"report.csv", "report.csv.part"},
+			pattern: "report*.csv",
+		},
+		{
+			name:    "partial download of other file",
+			files:   []string{"report.csv", "invoice.pdf.crdownload"},
+			pattern: "report*.csv",
+			want:    "report.csv",
+		},
+		{
+			name:    "tmp file is a finished download",
+			files:   []string{"export.tmp"},
+			pattern: "*.tmp",
+			want:    "export.tmp",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			for _, f := range tt.files {
+				err := os.WriteFile(filepath.Join(dir, f), nil, 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			s := &Session{downloadDir: dir}
+
+			got, err := s.findDownload(tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := tt.want
+			if want != "" {
+				want = filepath.Join(dir, want)
+			}
+
+			if got != want {
+				t.Errorf(
+					"findDownload(%q) = %q, want %q", tt.pattern, got, want,
+				)
+			}
+		})
+	}
+}
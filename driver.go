@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os/exec"
@@ -113,6 +114,28 @@ func (d *Driver) stop() error {
 	return nil
 }
 
+// isRemote checks if the browser driver runs on another machine, i.e., local
+// files are not accessible to it. Driver is considered remote if it is marked
+// as such in the config or its URL does not point to the loopback interface.
+func (d *Driver) isRemote() bool {
+	if config.WebDriver.Remote {
+		return true
+	}
+
+	u, err := url.Parse(d.remoteURL)
+	if err != nil {
+		return false
+	}
+
+	if u.Hostname() == "localhost" {
+		return false
+	}
+
+	ip := net.ParseIP(u.Hostname())
+
+	return ip == nil || !ip.IsLoopback()
+}
+
 // IsReady returns true if the browser driver is ready to create new sessions.
 // An error is returned if there was an issue retrieving driver's status.
 // TODO: make public and private methods.
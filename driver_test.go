@@ -0,0 +1,32 @@
+package selenium
+
+import "testing"
+
+func TestDriverIsRemote(t *testing.T) {
+	tests := []struct {
+		remoteURL string
+		remote    bool
+		want      bool
+	}{
+		{remoteURL: "http://localhost:4444"},
+		{remoteURL: "http://127.0.0.1:4444"},
+		{remoteURL: "http://[::1]:4444"},
+		{remoteURL: "http://localhost:4444", remote: true, want: true},
+		{remoteURL: "http://10.0.0.5:4444", want: true},
+		{remoteURL: "http://selenium-hub:4444/wd/hub", want: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.remoteURL, func(t *testing.T) {
+			setTestConfig(t).WebDriver.Remote = tt.remote
+
+			d := &Driver{remoteURL: tt.remoteURL}
+
+			if got := d.isRemote(); got != tt.want {
+				t.Errorf("isRemote() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
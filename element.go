@@ -20,8 +20,16 @@ type E struct {
 type Element struct {
 	E
 
-	id       string
-	session  *Session
+	id      string
+	session *Session
+	parent  *Element
+	// collection is set if the element is a part of Elements. In such case
+	// the element is located by its index in the collection.
+	collection *Elements
+	index      int
+	// scripted is set if the element is returned by a script. Such element
+	// has no selector, therefore, it cannot be located again.
+	scripted bool
 	settings *elementSettings
 	api      *apiClient
 }
@@ -32,6 +40,14 @@ const (
 	legacyElementID = "ELEMENT"
 )
 
+// elementReference is the JSON representation of a web element that is used
+// when an element is sent to the browser driver, e.g., as a script argument.
+//
+//nolint:tagliatelle
+type elementReference struct {
+	ID string `json:"element-6066-11e4-a52e-4f735466cecf"`
+}
+
 // NewElement returns a new Element. The parameter can be either a selector (
 // uses session's default locator) or *E or E struct.
 func (s *Session) NewElement(e interface{}) *Element {
@@ -77,6 +93,12 @@ func (s *Session) NewElement(e interface{}) *Element {
 	}
 }
 
+func (e *Element) reference() elementReference {
+	e.setElementID()
+
+	return elementReference{e.id}
+}
+
 func (e *Element) setElementID() {
 	if e.id != "" {
 		return
@@ -97,7 +119,9 @@ func (e *Element) setElementID() {
 	for time.Now().Before(timeout) {
 		id, err := e.findElement()
 		if err != nil {
-			handleError(nil, err)
+			e.session.handleError(nil, err)
+
+			return
 		}
 
 		if id == "" {
@@ -115,7 +139,7 @@ func (e *Element) setElementID() {
 		logger.Debugf("An error occurred while finding element: %s", err)
 	}
 
-	handleError(
+	e.session.handleError(
 		nil,
 		errors.Errorf(
 			"Element %q (%s) not found", e.Selector, e.SelectorType,
@@ -123,19 +147,37 @@ func (e *Element) setElementID() {
 	)
 }
 
+// newChildElement returns a new Element that is located within e.
+func (e *Element) newChildElement(sel interface{}) *Element {
+	child := e.session.NewElement(sel)
+	child.parent = e
+
+	return child
+}
+
 func (e *Element) findElement() (string, error) {
-	res, err := e.api.executeRequest(
-		http.MethodPost,
-		fmt.Sprintf("/session/%s/element", e.session.id),
-		e,
-	)
+	if e.collection != nil {
+		return e.findInCollection()
+	}
+
+	route := fmt.Sprintf("/session/%s/element", e.session.id)
+
+	if e.parent != nil {
+		e.parent.setElementID()
+
+		route = fmt.Sprintf(
+			"/session/%s/element/%s/element", e.session.id, e.parent.id,
+		)
+	}
+
+	res, err := e.api.executeRequest(http.MethodPost, route, e)
 	if err != nil {
 		errRes := res.getErrorReponse()
 		if errRes == nil {
 			return "", errors.Wrap(err, "failed to find element")
 		}
 
-		if errors.As(errRes, &types.ErrNoSuchElement) &&
+		if errors.Is(errRes, types.ErrNoSuchElement) &&
 			e.settings.IgnoreNotFound {
 			return "", nil
 		}
@@ -160,16 +202,47 @@ func (e *Element) findElement() (string, error) {
 	return id, nil
 }
 
+func (e *Element) findInCollection() (string, error) {
+	elements, err := e.collection.resolve()
+	if err != nil {
+		return "", err
+	}
+
+	if e.index < len(elements) {
+		return elements[e.index].id, nil
+	}
+
+	if e.settings.IgnoreNotFound {
+		return "", nil
+	}
+
+	return "", errors.Wrapf(
+		types.ErrNoSuchElement,
+		"element %q at index %d not found", e.Selector, e.index,
+	)
+}
+
+// staleErrors returns errors that are ignored while conditions are checked,
+// as the stale element is located again. Elements returned by scripts cannot
+// be located again, therefore, no errors are ignored for them.
+func (e *Element) staleErrors() []error {
+	if e.scripted {
+		return nil
+	}
+
+	return []error{types.ErrStaleElementReference}
+}
+
 func isAllowedError(err error) bool {
-	if errors.As(err, &types.ErrStaleElementReference) {
+	if errors.Is(err, types.ErrStaleElementReference) {
 		return true
 	}
 
-	if errors.As(err, &types.ErrElementlickIntercepted) {
+	if errors.Is(err, types.ErrElementlickIntercepted) {
 		return true
 	}
 
-	if errors.As(err, &types.ErrElementNotInteractable) {
+	if errors.Is(err, types.ErrElementNotInteractable) {
 		return true
 	}
 
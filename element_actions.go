@@ -3,6 +3,7 @@ package selenium
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/aleksslitvinovs/go-selenium/logger"
 	"github.com/aleksslitvinovs/go-selenium/types"
@@ -19,7 +20,7 @@ func (e *Element) GetText() string {
 		e,
 	)
 	if err != nil {
-		handleError(res, err)
+		e.session.handleError(res, err)
 	}
 
 	if res.Value == nil {
@@ -46,7 +47,7 @@ func (e *Element) GetAttribute(attribute string) string {
 		),
 	)
 	if err != nil {
-		handleError(res, err)
+		e.session.handleError(res, err)
 	}
 
 	if res.Value == nil {
@@ -64,6 +65,145 @@ func (e *Element) GetAttribute(attribute string) string {
 	return ""
 }
 
+// GetTagName returns the lowercase tag name of the element.
+func (e *Element) GetTagName() string {
+	e.setElementID()
+
+	res, err := e.api.executeRequestVoid(
+		http.MethodGet,
+		fmt.Sprintf("/session/%s/element/%s/name", e.session.id, e.id),
+	)
+	if err != nil {
+		e.session.handleError(res, err)
+
+		return ""
+	}
+
+	if v, ok := res.Value.(string); ok {
+		return strings.ToLower(v)
+	}
+
+	return ""
+}
+
+// GetProperty returns the value of the given DOM property of the element, e.g.,
+// current value of an input. If the element does not have the given property,
+// nil is returned.
+func (e *Element) GetProperty(property string) interface{} {
+	e.setElementID()
+
+	res, err := e.api.executeRequestVoid(
+		http.MethodGet,
+		fmt.Sprintf(
+			"/session/%s/element/%s/property/%s",
+			e.session.id, e.id, property,
+		),
+	)
+	if err != nil {
+		e.session.handleError(res, err)
+
+		return nil
+	}
+
+	return res.Value
+}
+
+// GetCSSValue returns the computed value of the given CSS property of the
+// element.
+func (e *Element) GetCSSValue(property string) string {
+	e.setElementID()
+
+	res, err := e.api.executeRequestVoid(
+		http.MethodGet,
+		fmt.Sprintf(
+			"/session/%s/element/%s/css/%s", e.session.id, e.id, property,
+		),
+	)
+	if err != nil {
+		e.session.handleError(res, err)
+
+		return ""
+	}
+
+	if v, ok := res.Value.(string); ok {
+		return v
+	}
+
+	return ""
+}
+
+// Rect describes element's position relative to the document and its size in
+// CSS pixels.
+type Rect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// GetRect returns the element's position and size.
+func (e *Element) GetRect() Rect {
+	rect, err := e.getRect()
+	if err != nil {
+		e.session.handleError(nil, err)
+	}
+
+	return rect
+}
+
+// getRect returns the element's position and size. Unlike GetRect, the error
+// is returned instead of being handled, so that a zero Rect is not mistaken
+// for the element's rect when soft assertions are used.
+func (e *Element) getRect() (Rect, error) {
+	e.setElementID()
+
+	var response struct {
+		Value Rect `json:"value"`
+	}
+
+	res, err := e.api.executeRequestCustom(
+		http.MethodGet,
+		fmt.Sprintf("/session/%s/element/%s/rect", e.session.id, e.id),
+		struct{}{},
+		&response,
+	)
+	if err != nil {
+		if errRes := res.getErrorReponse(); errRes != nil {
+			return Rect{}, errRes
+		}
+
+		return Rect{}, err
+	}
+
+	return response.Value, nil
+}
+
+// getValue returns the value of the element's endpoint, e.g., "text". Unlike
+// GetText, etc., the element is not waited for and the error is returned
+// instead of being handled, so that assertions can be retried.
+func (e *Element) getValue(endpoint string) (interface{}, error) {
+	err := e.locate()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := e.api.executeRequestVoid(
+		http.MethodGet,
+		fmt.Sprintf(
+			"/session/%s/element/%s/%s", e.session.id, e.id, endpoint,
+		),
+	)
+	if err != nil {
+		if errRes := res.getErrorReponse(); errRes != nil {
+			return nil, errRes
+		}
+
+		return nil, err
+	}
+
+	return res.Value, nil
+}
+
 // Click clicks on the element.
 func (e *Element) Click() *Element {
 	e.setElementID()
@@ -74,7 +214,7 @@ func (e *Element) Click() *Element {
 		e,
 	)
 	if err != nil {
-		handleError(res, err)
+		e.session.handleError(res, err)
 	}
 
 	return e
@@ -96,17 +236,17 @@ func (e *Element) SendKeys(input string) *Element {
 	if err != nil {
 		errRes := res.getErrorReponse()
 		if errRes == nil {
-			handleError(nil, err)
+			e.session.handleError(nil, err)
 
 			return e
 		}
 
-		if errors.As(errRes, &types.ErrNoSuchElement) &&
+		if errors.Is(errRes, types.ErrNoSuchElement) &&
 			e.settings.IgnoreNotFound {
 			return e
 		}
 
-		handleError(res, err)
+		e.session.handleError(res, err)
 	}
 
 	return e
@@ -122,7 +262,27 @@ func (e *Element) Clear() *Element {
 		e,
 	)
 	if err != nil {
-		handleError(res, err)
+		e.session.handleError(res, err)
+	}
+
+	return e
+}
+
+// Check checks the checkbox or radio button if it is not checked yet.
+func (e *Element) Check() *Element {
+	return e.SetChecked(true)
+}
+
+// Uncheck unchecks the checkbox if it is checked.
+func (e *Element) Uncheck() *Element {
+	return e.SetChecked(false)
+}
+
+// SetChecked clicks on the checkbox or radio button if its current state
+// differs from the given.
+func (e *Element) SetChecked(checked bool) *Element {
+	if e.IsSelected() != checked {
+		e.Click()
 	}
 
 	return e
@@ -132,7 +292,7 @@ func (e *Element) Clear() *Element {
 func (e *Element) IsPresent() bool {
 	id, err := e.findElement()
 	if err != nil {
-		handleError(nil, err)
+		e.session.handleError(nil, err)
 	}
 
 	e.id = id
@@ -202,13 +362,15 @@ func (e *Element) handleCondition(
 ) bool {
 	res, err := condition()
 	if err != nil {
-		handleError(res, err)
+		e.session.handleError(res, err)
 
 		return false
 	}
 
 	if res.Value == nil {
-		handleError(nil, errors.New("failed top get element's condition"))
+		e.session.handleError(
+			nil, errors.New("failed top get element's condition"),
+		)
 
 		return false
 	}
@@ -219,3 +381,22 @@ func (e *Element) handleCondition(
 
 	return false
 }
+
+// isObscuredScript checks if the element's center point is covered by another
+// element that is not element's descendant. The element is scrolled to the
+// center of the viewport first, as elementFromPoint only finds elements within
+// the viewport, therefore, the check changes the page's scroll position.
+const isObscuredScript = `function (e) {
+	e.scrollIntoView({ block: "center", inline: "center" });
+
+	const rect = e.getBoundingClientRect();
+	const target = document.elementFromPoint(
+		rect.left + rect.width / 2, rect.top + rect.height / 2,
+	);
+
+	return target === null || (target !== e && !e.contains(target));
+}`
+
+func (e *Element) isObscured() bool {
+	return ExecuteScriptInto[bool](e.session, isObscuredScript, e)
+}
@@ -2,56 +2,124 @@ package selenium
 
 import (
 	"fmt"
-	"strings"
+	"regexp"
+	"time"
 
-	"github.com/aleksslitvinovs/go-selenium/logger"
+	"github.com/aleksslitvinovs/go-selenium/types"
 	"github.com/pkg/errors"
 )
 
 // Asserter is a helper struct to assert the element's text, attributes, etc.
+// Assertions are retried until they pass or the timeout expires.
 type Asserter struct {
-	e *Element
+	e       *Element
+	timeout time.Duration
 }
 
-// Valuer is a helper struct to compare the actual value of an element with the
-// expected using various comparison functions.
+// Valuer is a helper struct to compare the actual value of an element or a page
+// with the expected using various comparison functions. The actual value is
+// retrieved again until the comparison passes or the timeout expires.
 type Valuer struct {
-	actual   string
+	get func() (string, error)
+	// getAny is set instead of get if the assertion passes when any of the
+	// values satisfies it, e.g., text of any element in a collection.
+	getAny func() ([]string, error)
+	actual []string
+	// absent is returned by get if the value does not exist, e.g., cookie is
+	// not set. Negated assertions pass if the value is absent.
+	absent error
+	// Either e, ee or s is set depending on whether element's, collection's
+	// or page's value is asserted.
 	e        *Element
+	ee       *Elements
+	s        *Session
+	subject  string
 	property string
 	isEqual  bool
+	timeout  time.Duration
 }
 
 // ShouldHave returns an Asserter for the given element. The returned Asserter
 // can be used to assert the element's text, attributes, etc.
 func (e *Element) ShouldHave() *Asserter {
 	return &Asserter{
-		e: e,
+		e:       e,
+		timeout: e.settings.RetryTimeout.Duration,
 	}
 }
 
+// Within overrides the time during which the assertions are retried. Defaults
+// to element.retry_timeout from the config.
+func (a *Asserter) Within(timeout time.Duration) *Asserter {
+	a.timeout = timeout
+
+	return a
+}
+
 // Text allows asserting the element's text.
 func (a *Asserter) Text() *Valuer {
-	text := a.e.GetText()
+	return a.newValuer("text", func() (string, error) {
+		v, err := a.e.getValue("text")
+		text, _ := v.(string)
 
-	return &Valuer{
-		actual:   text,
-		e:        a.e,
-		property: "text",
-		isEqual:  true,
-	}
+		return text, err
+	})
 }
 
-// Attribute allows asserting the element's attributes.
+// Attribute allows asserting the element's attributes. Missing attribute is
+// treated as an empty string.
 func (a *Asserter) Attribute(attribute string) *Valuer {
+	return a.newValuer(
+		fmt.Sprintf("attribute %q", attribute),
+		func() (string, error) {
+			v, err := a.e.getValue("attribute/" + attribute)
+			value, _ := v.(string)
+
+			return value, err
+		},
+	)
+}
+
+// SelectedOption allows asserting the visible text of the first selected option
+// of the select element.
+func (a *Asserter) SelectedOption() *Valuer {
+	return a.newValuer("selected option", a.e.selectedOptionText)
+}
+
+// Checked asserts that the checkbox or radio button is checked.
+func (a *Asserter) Checked() {
+	a.e.assertState(a.timeout, a.e.whenPresent(a.e.IsSelected), "checked")
+}
+
+// Unchecked asserts that the checkbox or radio button is not checked.
+func (a *Asserter) Unchecked() {
+	a.e.assertState(
+		a.timeout, a.e.whenPresent(negate(a.e.IsSelected)), "unchecked",
+	)
+}
+
+func (a *Asserter) newValuer(
+	property string, get func() (string, error),
+) *Valuer {
 	return &Valuer{
-		actual:   a.e.GetAttribute(attribute),
+		get:      get,
 		e:        a.e,
-		property: fmt.Sprintf("attribute %q", attribute),
+		subject:  "element's",
+		property: property,
 		isEqual:  true,
+		timeout:  a.timeout,
 	}
 }
 
+// retryOptions returns options that are used to retry element's assertions.
+func (e *Element) retryOptions(timeout time.Duration) *WaitOptions {
+	return (&WaitOptions{
+		Timeout:       timeout,
+		PollInterval:  e.settings.PollInterval.Duration,
+		IgnoredErrors: e.staleErrors(),
+	}).withDefaults()
+}
+
 // Not negates the following assertion.
 func (v *Valuer) Not() *Valuer {
 	v.isEqual = false
@@ -59,74 +127,241 @@ func (v *Valuer) Not() *Valuer {
 	return v
 }
 
-type comparer struct {
-	past    string
-	present string
-}
+// Within overrides the time during which the assertion is retried. Defaults to
+// element.retry_timeout from the config.
+func (v *Valuer) Within(timeout time.Duration) *Valuer {
+	v.timeout = timeout
 
-var (
-	equalTo   = comparer{"been equal to", "equal"}
-	startWith = comparer{"started with", "start with"}
-	endWith   = comparer{"ended with", "end with"}
-	contain   = comparer{"contained", "contain"}
-)
+	return v
+}
 
-// EqualTo asserts that the actual value of the element is equal to the given.
+// EqualTo asserts that the actual value is equal to the given.
 func (v *Valuer) EqualTo(expected string) {
-	v.compare(expected, equalTo)
+	v.compare(equalTo(expected))
 }
 
-// StartWith asserts that the actual value of the element starts with the
-// given.
+// StartWith asserts that the actual value starts with the given.
 func (v *Valuer) StartWith(expected string) {
-	v.compare(expected, startWith)
+	v.compare(startWith(expected))
 }
 
-// EndWith asserts that the actual value of the element ends with the given.
+// EndWith asserts that the actual value ends with the given.
 func (v *Valuer) EndWith(expected string) {
-	v.compare(expected, endWith)
+	v.compare(endWith(expected))
 }
 
-// Contain asserts that the actual value of the element contains the given.
+// Contain asserts that the actual value contains the given.
 func (v *Valuer) Contain(expected string) {
-	v.compare(expected, contain)
+	v.compare(contain(expected))
 }
 
-func (v *Valuer) compare(expected string, cmp comparer) {
-	var result bool
+// Match asserts that the actual value matches the given regular expression.
+func (v *Valuer) Match(re *regexp.Regexp) {
+	v.compare(matchRegexp(re))
+}
 
-	switch cmp {
-	case equalTo:
-		result = v.actual == expected
-	case startWith:
-		result = strings.HasPrefix(v.actual, expected)
-	case endWith:
-		result = strings.HasSuffix(v.actual, expected)
-	case contain:
-		result = strings.Contains(v.actual, expected)
-	}
+// EqualToIgnoringCase asserts that the actual value is equal to the given,
+// ignoring case.
+func (v *Valuer) EqualToIgnoringCase(expected string) {
+	v.compare(equalToIgnoringCase(expected))
+}
+
+// ContainIgnoringCase asserts that the actual value contains the given,
+// ignoring case.
+func (v *Valuer) ContainIgnoringCase(expected string) {
+	v.compare(containIgnoringCase(expected))
+}
+
+// EqualToIgnoringWhitespace asserts that the actual value is equal to the
+// given after both are trimmed and whitespace sequences are replaced with a
+// single space.
+func (v *Valuer) EqualToIgnoringWhitespace(expected string) {
+	v.compare(equalToIgnoringWhitespace(expected))
+}
+
+// GreaterThan asserts that the actual value is a number that is greater than
+// the given.
+func (v *Valuer) GreaterThan(expected float64) {
+	v.compare(greaterThan(expected))
+}
+
+// LessThan asserts that the actual value is a number that is less than the
+// given.
+func (v *Valuer) LessThan(expected float64) {
+	v.compare(lessThan(expected))
+}
+
+// OneOf asserts that the actual value is equal to one of the given.
+func (v *Valuer) OneOf(expected ...string) {
+	v.compare(oneOf(expected))
+}
+
+// Empty asserts that the actual value is an empty string.
+func (v *Valuer) Empty() {
+	v.compare(equalTo(""))
+}
+
+// Satisfy asserts that the actual value satisfies the given matcher.
+func (v *Valuer) Satisfy(m Matcher) {
+	v.compare(m)
+}
+
+func (v *Valuer) compare(m Matcher) {
+	var matchErr, getErr error
+
+	err := v.retry(func() (bool, error) {
+		actual, err := v.values()
+
+		getErr = err
+		if err != nil {
+			if !v.isEqual && v.absent != nil && errors.Is(err, v.absent) {
+				return true, nil
+			}
+
+			return false, err
+		}
 
-	not := comparer{"", "does"}
+		v.actual = actual
+
+		var matched bool
+
+		for _, value := range actual {
+			var ok bool
+
+			ok, matchErr = m.Match(value)
+			if ok {
+				matched = true
+
+				break
+			}
+		}
+
+		// The value may become comparable later, e.g., when it is loaded.
+		if !matched && matchErr != nil {
+			return false, nil
+		}
+
+		return xnor(matched, v.isEqual), nil
+	})
+
+	not := struct{ past, present string }{"", "does"}
 	if !v.isEqual {
-		not = comparer{"not ", "does not"}
+		not.past, not.present = "not ", "does not"
 	}
 
-	if xnor(result, v.isEqual) {
-		logger.Infof(
-			"element's %s %s %s %q",
-			v.property, not.present, cmp.present, expected,
+	if err == nil {
+		v.session().pass(
+			"%s %s %s %s", v.subject, v.property, not.present, m.Present(),
 		)
 
 		return
 	}
 
-	handleError(
-		nil,
-		errors.Errorf(
-			"element's %s should have %s%s %q, actual value %q",
-			v.property, not.past, cmp.past, expected, v.actual,
-		),
+	f := &AssertionFailure{
+		Selector: v.selector(),
+		Property: v.property,
+		Expected: not.past + m.Present(),
+	}
+
+	if !isWaitTimeout(err) {
+		f.Message = errors.Wrapf(
+			err, "failed to assert %s %s", v.subject, v.property,
+		).Error()
+
+		v.session().fail(f)
+
+		return
+	}
+
+	f.Actual = v.actualValue()
+	f.Message = fmt.Sprintf(
+		"%s %s should have %s%s, %s",
+		v.subject, v.property, not.past, m.Past(), v.describeActual(),
 	)
+
+	switch {
+	case getErr != nil:
+		f.Message = fmt.Sprintf("%s (%s)", f.Message, getErr)
+	case matchErr != nil:
+		f.Message = fmt.Sprintf("%s (%s)", f.Message, matchErr)
+	}
+
+	v.session().fail(f)
+}
+
+// retry checks the condition until it returns true or the timeout expires.
+// Element is located again if it becomes stale.
+func (v *Valuer) retry(condition func() (bool, error)) error {
+	condition = v.session().checking(condition)
+
+	if v.e != nil {
+		// The element may appear later, therefore, it is looked up again.
+		opts := v.e.retryOptions(v.timeout)
+		opts.IgnoredErrors = append(opts.IgnoredErrors, types.ErrNoSuchElement)
+
+		return poll(opts, v.e.relocating(condition))
+	}
+
+	if v.ee != nil {
+		return poll(v.ee.retryOptions(v.timeout), condition)
+	}
+
+	return poll(v.s.retryOptions(v.timeout), condition)
+}
+
+func (v *Valuer) values() ([]string, error) {
+	if v.getAny != nil {
+		return v.getAny()
+	}
+
+	actual, err := v.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{actual}, nil
+}
+
+func (v *Valuer) describeActual() string {
+	if v.getAny != nil {
+		return fmt.Sprintf("actual values %s", v.actualValue())
+	}
+
+	return fmt.Sprintf("actual value %q", v.actualValue())
+}
+
+func (v *Valuer) actualValue() string {
+	if v.getAny != nil {
+		return fmt.Sprintf("%q", v.actual)
+	}
+
+	if len(v.actual) == 0 {
+		return ""
+	}
+
+	return v.actual[0]
+}
+
+func (v *Valuer) session() *Session {
+	switch {
+	case v.e != nil:
+		return v.e.session
+	case v.ee != nil:
+		return v.ee.session
+	default:
+		return v.s
+	}
+}
+
+func (v *Valuer) selector() string {
+	switch {
+	case v.e != nil:
+		return v.e.Selector
+	case v.ee != nil:
+		return v.ee.Selector
+	default:
+		return ""
+	}
 }
 
 func xnor(a, b bool) bool {
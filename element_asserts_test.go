@@ -0,0 +1,120 @@
+package selenium
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// noSuchElement is the response of the fake driver to a missing element.
+var noSuchElement = fakeResponse{
+	status: http.StatusNotFound,
+	value: map[string]string{
+		"error":   "no such element",
+		"message": "unable to locate element",
+	},
+}
+
+func TestAsserterMissingElement(t *testing.T) {
+	tests := []struct {
+		name   string
+		assert func(a *Asserter)
+	}{
+		{
+			name:   "text is not equal",
+			assert: func(a *Asserter) { a.Text().Not().EqualTo("Hello") },
+		},
+		{
+			name:   "text is empty",
+			assert: func(a *Asserter) { a.Text().Empty() },
+		},
+		{
+			name: "attribute is not equal",
+			assert: func(a *Asserter) {
+				a.Attribute("class").Not().EqualTo("active")
+			},
+		},
+		{
+			name:   "selected option is empty",
+			assert: func(a *Asserter) { a.SelectedOption().Empty() },
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t).SoftAsserts = true
+
+			s := newFakeSession(t, map[string]fakeResponse{
+				"POST /session/fake/element": noSuchElement,
+			})
+
+			startTime := time.Now()
+
+			tt.assert(s.NewElement("#missing").ShouldHave().Within(
+				50 * time.Millisecond,
+			))
+
+			elapsed := time.Since(startTime)
+			if elapsed < 50*time.Millisecond || elapsed > 150*time.Millisecond {
+				t.Errorf("assertion is retried for %s", elapsed)
+			}
+
+			failures := s.Failures()
+			if len(failures) != 1 ||
+				!strings.Contains(failures[0].Message, "not found") {
+				t.Errorf("expected missing element to fail, got %v", failures)
+			}
+		})
+	}
+}
+
+func TestAsserterText(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/element":        fakeElement,
+		"GET /session/fake/element/el/text": {value: "Hello"},
+		"GET /session/fake/element/el/attribute/class": {
+			value: "active",
+		},
+	})
+
+	e := s.NewElement("#greeting")
+
+	e.ShouldHave().Text().EqualTo("Hello")
+	e.ShouldHave().Attribute("class").EqualTo("active")
+	e.ShouldHave().Within(50 * time.Millisecond).Text().Not().EqualTo("Hello")
+
+	failures := s.Failures()
+	if len(failures) != 1 || failures[0].Expected != `not equal "Hello"` {
+		t.Errorf("expected only negated assertion to fail, got %v", failures)
+	}
+}
+
+func TestAsserterSelectedOption(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/element": {
+			value: map[string]string{webElementID: "sel"},
+		},
+		"POST /session/fake/element/sel/elements": {
+			value: []map[string]string{
+				{webElementID: "o1"},
+				{webElementID: "o2"},
+			},
+		},
+		"GET /session/fake/element/o1/selected": {value: false},
+		"GET /session/fake/element/o2/selected": {value: true},
+		"GET /session/fake/element/o2/text":     {value: "Two"},
+	})
+
+	s.NewElement("#size").ShouldHave().SelectedOption().EqualTo("Two")
+
+	if failures := s.Failures(); len(failures) != 0 {
+		t.Errorf("unexpected failures %v", failures)
+	}
+}
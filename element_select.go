@@ -0,0 +1,243 @@
+package selenium
+
+import (
+	"strings"
+
+	"github.com/aleksslitvinovs/go-selenium/selectors"
+	"github.com/pkg/errors"
+)
+
+// Select is a helper struct to interact with <select> element and its options.
+type Select struct {
+	e *Element
+}
+
+// NewSelect returns a new Select for the given <select> element.
+func NewSelect(e *Element) *Select {
+	return &Select{e: e}
+}
+
+// Element returns the underlying <select> element.
+func (s *Select) Element() *Element {
+	return s.e
+}
+
+// IsMultiple checks if multiple options can be selected at the same time.
+func (s *Select) IsMultiple() bool {
+	v, ok := s.e.GetProperty("multiple").(bool)
+
+	return ok && v
+}
+
+// Options returns all options of the select element.
+func (s *Select) Options() []*Element {
+	return s.e.newChildElements(E{
+		Selector:     "option",
+		SelectorType: selectors.TagName,
+	}).Elements()
+}
+
+// SelectedOptions returns all selected options of the select element.
+func (s *Select) SelectedOptions() []*Element {
+	options := s.Options()
+
+	selected := make([]*Element, 0, len(options))
+
+	for _, o := range options {
+		if o.IsSelected() {
+			selected = append(selected, o)
+		}
+	}
+
+	return selected
+}
+
+// FirstSelectedOption returns the first selected option. If no option is
+// selected, nil is returned.
+func (s *Select) FirstSelectedOption() *Element {
+	for _, o := range s.Options() {
+		if o.IsSelected() {
+			return o
+		}
+	}
+
+	return nil
+}
+
+// selectedOptionText returns the visible text of the first selected option.
+// If no option is selected, an empty string is returned. Unlike
+// FirstSelectedOption, the select element is not waited for and the error is
+// returned instead of being handled.
+func (e *Element) selectedOptionText() (string, error) {
+	err := e.locate()
+	if err != nil {
+		return "", err
+	}
+
+	options, err := e.newChildElements(E{
+		Selector:     "option",
+		SelectorType: selectors.TagName,
+	}).resolve()
+	if err != nil {
+		return "", err
+	}
+
+	for _, o := range options {
+		selected, err := o.getValue("selected")
+		if err != nil {
+			return "", err
+		}
+
+		if ok, _ := selected.(bool); !ok {
+			continue
+		}
+
+		text, err := o.getValue("text")
+		v, _ := text.(string)
+
+		return v, err
+	}
+
+	return "", nil
+}
+
+// SelectByText selects options that have the given visible text. For single
+// select elements only the first matching option is selected.
+func (s *Select) SelectByText(text string) *Select {
+	return s.selectMatching(
+		func(o *Element, _ int) bool {
+			return strings.TrimSpace(o.GetText()) == strings.TrimSpace(text)
+		},
+		true,
+		"text", text,
+	)
+}
+
+// SelectByValue selects options that have the given value attribute. For
+// single select elements only the first matching option is selected.
+func (s *Select) SelectByValue(value string) *Select {
+	return s.selectMatching(
+		func(o *Element, _ int) bool { return optionValue(o) == value },
+		true,
+		"value", value,
+	)
+}
+
+// SelectByIndex selects the option at the given index (starting from 0).
+func (s *Select) SelectByIndex(index int) *Select {
+	return s.selectMatching(
+		func(_ *Element, i int) bool { return i == index },
+		true,
+		"index", index,
+	)
+}
+
+// DeselectByText deselects options that have the given visible text. Only
+// options of multi-select elements can be deselected.
+func (s *Select) DeselectByText(text string) *Select {
+	return s.selectMatching(
+		func(o *Element, _ int) bool {
+			return strings.TrimSpace(o.GetText()) == strings.TrimSpace(text)
+		},
+		false,
+		"text", text,
+	)
+}
+
+// DeselectByValue deselects options that have the given value attribute. Only
+// options of multi-select elements can be deselected.
+func (s *Select) DeselectByValue(value string) *Select {
+	return s.selectMatching(
+		func(o *Element, _ int) bool { return optionValue(o) == value },
+		false,
+		"value", value,
+	)
+}
+
+// DeselectByIndex deselects the option at the given index (starting from 0).
+// Only options of multi-select elements can be deselected.
+func (s *Select) DeselectByIndex(index int) *Select {
+	return s.selectMatching(
+		func(_ *Element, i int) bool { return i == index },
+		false,
+		"index", index,
+	)
+}
+
+// DeselectAll deselects all selected options. Only options of multi-select
+// elements can be deselected.
+func (s *Select) DeselectAll() *Select {
+	if !s.IsMultiple() {
+		s.e.session.handleError(
+			nil,
+			errors.Errorf(
+				"cannot deselect options of single select %q", s.e.Selector,
+			),
+		)
+
+		return s
+	}
+
+	for _, o := range s.SelectedOptions() {
+		o.Click()
+	}
+
+	return s
+}
+
+func (s *Select) selectMatching(
+	matches func(o *Element, i int) bool,
+	selected bool,
+	by string,
+	value interface{},
+) *Select {
+	multiple := s.IsMultiple()
+
+	if !selected && !multiple {
+		s.e.session.handleError(
+			nil,
+			errors.Errorf(
+				"cannot deselect options of single select %q", s.e.Selector,
+			),
+		)
+
+		return s
+	}
+
+	var found bool
+
+	for i, o := range s.Options() {
+		if !matches(o, i) {
+			continue
+		}
+
+		found = true
+
+		o.SetChecked(selected)
+
+		if !multiple {
+			break
+		}
+	}
+
+	if !found {
+		s.e.session.handleError(
+			nil,
+			errors.Errorf(
+				"select %q does not have option with %s %v",
+				s.e.Selector, by, value,
+			),
+		)
+	}
+
+	return s
+}
+
+func optionValue(o *Element) string {
+	v, ok := o.GetProperty("value").(string)
+	if !ok {
+		return ""
+	}
+
+	return v
+}
@@ -0,0 +1,128 @@
+package selenium
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelectMatching(t *testing.T) {
+	tests := []struct {
+		name     string
+		multiple bool
+		selected string
+		action   func(s *Select)
+		clicks   []string
+		failure  string
+	}{
+		{
+			name:   "single select by text",
+			action: func(s *Select) { s.SelectByText(" Two ") },
+			clicks: []string{"o2"},
+		},
+		{
+			name:     "multiple select by text",
+			multiple: true,
+			action:   func(s *Select) { s.SelectByText("Two") },
+			clicks:   []string{"o2", "o3"},
+		},
+		{
+			name:   "select by value",
+			action: func(s *Select) { s.SelectByValue("3") },
+			clicks: []string{"o3"},
+		},
+		{
+			name:     "select already selected option",
+			selected: "o1",
+			action:   func(s *Select) { s.SelectByIndex(0) },
+		},
+		{
+			name:    "select missing option",
+			action:  func(s *Select) { s.SelectByValue("4") },
+			failure: `does not have option with value 4`,
+		},
+		{
+			name:     "deselect by index",
+			multiple: true,
+			selected: "o1",
+			action:   func(s *Select) { s.DeselectByIndex(0) },
+			clicks:   []string{"o1"},
+		},
+		{
+			name:     "deselect single select",
+			selected: "o1",
+			action:   func(s *Select) { s.DeselectByText("One") },
+			failure:  "cannot deselect options of single select",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t).SoftAsserts = true
+
+			routes := map[string]fakeResponse{
+				"POST /session/fake/element": {
+					value: map[string]string{webElementID: "sel"},
+				},
+				"GET /session/fake/element/sel/property/multiple": {
+					value: tt.multiple,
+				},
+				"POST /session/fake/element/sel/elements": {
+					value: []map[string]string{
+						{webElementID: "o1"},
+						{webElementID: "o2"},
+						{webElementID: "o3"},
+					},
+				},
+			}
+
+			options := []struct{ id, text, value string }{
+				{"o1", "One", "1"},
+				{"o2", "Two", "2"},
+				{"o3", "Two", "3"},
+			}
+
+			for _, o := range options {
+				prefix := "/session/fake/element/" + o.id
+
+				routes["GET "+prefix+"/text"] = fakeResponse{value: o.text}
+				routes["GET "+prefix+"/property/value"] = fakeResponse{
+					value: o.value,
+				}
+				routes["GET "+prefix+"/selected"] = fakeResponse{
+					value: o.id == tt.selected,
+				}
+				routes["POST "+prefix+"/click"] = fakeResponse{}
+			}
+
+			s := newFakeSession(t, routes)
+
+			tt.action(NewSelect(s.NewElement("#sel")))
+
+			var clicks []string
+
+			for _, o := range options {
+				route := "POST /session/fake/element/" + o.id + "/click"
+				if requests(s, route) > 0 {
+					clicks = append(clicks, o.id)
+				}
+			}
+
+			if !equalStrings(clicks, tt.clicks) {
+				t.Errorf("expected %v to be clicked, got %v", tt.clicks, clicks)
+			}
+
+			failures := s.Failures()
+
+			if tt.failure == "" && len(failures) != 0 {
+				t.Errorf("unexpected failures %v", failures)
+			}
+
+			if tt.failure != "" && (len(failures) != 1 ||
+				!strings.Contains(failures[0].Message, tt.failure)) {
+				t.Errorf("expected failure %q, got %v", tt.failure, failures)
+			}
+		})
+	}
+}
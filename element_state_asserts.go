@@ -0,0 +1,232 @@
+package selenium
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	isFocusedScript = `function (e) { return document.activeElement === e }`
+
+	isInViewportScript = `function (e) {
+	const rect = e.getBoundingClientRect();
+	const height = window.innerHeight || document.documentElement.clientHeight;
+	const width = window.innerWidth || document.documentElement.clientWidth;
+
+	return rect.bottom > 0 && rect.right > 0 &&
+		rect.top < height && rect.left < width;
+}`
+)
+
+// StateAsserter is a helper struct to assert the element's state, e.g.,
+// visibility. Assertions are retried until they pass or the timeout expires.
+type StateAsserter struct {
+	e       *Element
+	timeout time.Duration
+}
+
+// ShouldBe returns a StateAsserter for the given element. The returned
+// StateAsserter can be used to assert whether the element is visible, enabled,
+// etc.
+func (e *Element) ShouldBe() *StateAsserter {
+	return &StateAsserter{
+		e:       e,
+		timeout: e.settings.RetryTimeout.Duration,
+	}
+}
+
+// Within overrides the time during which the assertions are retried. Defaults
+// to element.retry_timeout from the config.
+func (a *StateAsserter) Within(timeout time.Duration) *StateAsserter {
+	a.timeout = timeout
+
+	return a
+}
+
+// Present asserts that the element is present in the DOM.
+func (a *StateAsserter) Present() {
+	a.e.assertState(a.timeout, a.e.lookup, "present")
+}
+
+// NotPresent asserts that the element is not present in the DOM.
+func (a *StateAsserter) NotPresent() {
+	a.e.assertState(
+		a.timeout,
+		func() (bool, error) {
+			ok, err := a.e.lookup()
+
+			return !ok, err
+		},
+		"not present",
+	)
+}
+
+// Visible asserts that the element is visible.
+func (a *StateAsserter) Visible() {
+	a.e.assertState(a.timeout, a.e.whenPresent(a.e.IsVisible), "visible")
+}
+
+// Hidden asserts that the element is either not visible or not present.
+func (a *StateAsserter) Hidden() {
+	a.e.assertState(
+		a.timeout,
+		func() (bool, error) {
+			ok, err := a.e.lookup()
+			if err != nil || !ok {
+				return !ok, err
+			}
+
+			return !a.e.IsVisible(), nil
+		},
+		"hidden",
+	)
+}
+
+// Enabled asserts that the element is enabled.
+func (a *StateAsserter) Enabled() {
+	a.e.assertState(a.timeout, a.e.whenPresent(a.e.IsEnabled), "enabled")
+}
+
+// Disabled asserts that the element is disabled.
+func (a *StateAsserter) Disabled() {
+	a.e.assertState(
+		a.timeout, a.e.whenPresent(negate(a.e.IsEnabled)), "disabled",
+	)
+}
+
+// Selected asserts that the element, e.g., checkbox or option, is selected.
+func (a *StateAsserter) Selected() {
+	a.e.assertState(a.timeout, a.e.whenPresent(a.e.IsSelected), "selected")
+}
+
+// NotSelected asserts that the element, e.g., checkbox or option, is not
+// selected.
+func (a *StateAsserter) NotSelected() {
+	a.e.assertState(
+		a.timeout, a.e.whenPresent(negate(a.e.IsSelected)), "not selected",
+	)
+}
+
+// Focused asserts that the element is the document's active element.
+func (a *StateAsserter) Focused() {
+	a.e.assertState(
+		a.timeout,
+		a.e.whenPresent(func() bool {
+			return ExecuteScriptInto[bool](a.e.session, isFocusedScript, a.e)
+		}),
+		"focused",
+	)
+}
+
+// InViewport asserts that at least part of the element is within the
+// browser's viewport.
+func (a *StateAsserter) InViewport() {
+	a.e.assertState(
+		a.timeout,
+		a.e.whenPresent(func() bool {
+			return ExecuteScriptInto[bool](
+				a.e.session, isInViewportScript, a.e,
+			)
+		}),
+		"in viewport",
+	)
+}
+
+func (e *Element) assertState(
+	timeout time.Duration, condition func() (bool, error), stateName string,
+) {
+	err := poll(
+		e.retryOptions(timeout), e.relocating(e.session.checking(condition)),
+	)
+	if err == nil {
+		e.session.pass("element %q is %s", e.Selector, stateName)
+
+		return
+	}
+
+	f := &AssertionFailure{
+		Selector: e.Selector,
+		Property: "state",
+		Expected: stateName,
+	}
+
+	if !isWaitTimeout(err) {
+		f.Message = errors.Wrapf(
+			err, "failed to check if element %q is %s", e.Selector, stateName,
+		).Error()
+
+		e.session.fail(f)
+
+		return
+	}
+
+	f.Message = fmt.Sprintf(
+		"element %q should have been %s", e.Selector, stateName,
+	)
+
+	e.session.fail(f)
+}
+
+// lookup locates the element without waiting for it to appear. False is
+// returned if the element is not present.
+func (e *Element) lookup() (bool, error) {
+	initialSettings := e.settings
+
+	settings := *e.settings
+	settings.IgnoreNotFound = true
+	e.settings = &settings
+
+	defer func() {
+		e.settings = initialSettings
+	}()
+
+	id, err := e.findElement()
+	if err != nil {
+		return false, err
+	}
+
+	e.id = id
+
+	return id != "", nil
+}
+
+// locate locates the element without waiting for it to appear, unless it is
+// located already. ErrNoSuchElement is returned if the element is not present.
+func (e *Element) locate() error {
+	if e.id != "" {
+		return nil
+	}
+
+	ok, err := e.lookup()
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.Wrapf(
+			types.ErrNoSuchElement, "element %q not found", e.Selector,
+		)
+	}
+
+	return nil
+}
+
+// whenPresent returns a condition that is satisfied if the element is present
+// and the given state check returns true.
+func (e *Element) whenPresent(state func() bool) func() (bool, error) {
+	return func() (bool, error) {
+		ok, err := e.lookup()
+		if err != nil || !ok {
+			return false, err
+		}
+
+		return state(), nil
+	}
+}
+
+func negate(state func() bool) func() bool {
+	return func() bool { return !state() }
+}
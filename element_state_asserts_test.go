@@ -0,0 +1,110 @@
+package selenium
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStateAsserter(t *testing.T) {
+	present := map[string]fakeResponse{
+		"POST /session/fake/element":             fakeElement,
+		"GET /session/fake/element/el/displayed": {value: true},
+		"GET /session/fake/element/el/enabled":   {value: false},
+		"GET /session/fake/element/el/selected":  {value: true},
+	}
+
+	missing := map[string]fakeResponse{
+		"POST /session/fake/element": noSuchElement,
+	}
+
+	tests := []struct {
+		name   string
+		routes map[string]fakeResponse
+		assert func(a *StateAsserter)
+		pass   bool
+	}{
+		{
+			name:   "present",
+			routes: present,
+			assert: (*StateAsserter).Present,
+			pass:   true,
+		},
+		{
+			name:   "visible",
+			routes: present,
+			assert: (*StateAsserter).Visible,
+			pass:   true,
+		},
+		{name: "hidden", routes: present, assert: (*StateAsserter).Hidden},
+		{
+			name:   "disabled",
+			routes: present,
+			assert: (*StateAsserter).Disabled,
+			pass:   true,
+		},
+		{name: "enabled", routes: present, assert: (*StateAsserter).Enabled},
+		{
+			name:   "selected",
+			routes: present,
+			assert: (*StateAsserter).Selected,
+			pass:   true,
+		},
+		{
+			name:   "missing not present",
+			routes: missing,
+			assert: (*StateAsserter).NotPresent,
+			pass:   true,
+		},
+		{
+			name:   "missing hidden",
+			routes: missing,
+			assert: (*StateAsserter).Hidden,
+			pass:   true,
+		},
+		{
+			name:   "missing visible",
+			routes: missing,
+			assert: (*StateAsserter).Visible,
+		},
+		{
+			name:   "missing disabled",
+			routes: missing,
+			assert: (*StateAsserter).Disabled,
+		},
+		{
+			name:   "missing present",
+			routes: missing,
+			assert: (*StateAsserter).Present,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t).SoftAsserts = true
+
+			s := newFakeSession(t, tt.routes)
+
+			startTime := time.Now()
+
+			tt.assert(s.NewElement("#box").ShouldBe().Within(
+				50 * time.Millisecond,
+			))
+
+			elapsed := time.Since(startTime)
+			if elapsed > 150*time.Millisecond {
+				t.Errorf("assertion is retried for %s", elapsed)
+			}
+
+			failures := s.Failures()
+			if tt.pass && len(failures) != 0 {
+				t.Errorf("unexpected failures %v", failures)
+			}
+
+			if !tt.pass && len(failures) != 1 {
+				t.Errorf("expected 1 failure, got %v", failures)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package selenium
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// UploadFile sets the files of the file input element. All files must exist on
+// the local machine. If the browser driver runs on another machine (see
+// webdriver.remote config option), files are uploaded to it first.
+func (e *Element) UploadFile(paths ...string) *Element {
+	if len(paths) == 0 {
+		e.session.handleError(
+			nil, errors.New("at least one file must be provided"),
+		)
+
+		return e
+	}
+
+	files := make([]string, 0, len(paths))
+
+	for _, p := range paths {
+		f, err := e.session.prepareFile(p)
+		if err != nil {
+			e.session.handleError(
+				nil, errors.Wrapf(err, "failed to prepare %q for upload", p),
+			)
+
+			return e
+		}
+
+		files = append(files, f)
+	}
+
+	return e.SendKeys(strings.Join(files, "\n"))
+}
+
+// prepareFile validates that the file exists and returns its path that is
+// accessible to the browser driver.
+func (s *Session) prepareFile(p string) (string, error) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get absolute file path")
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to stat file")
+	}
+
+	if info.IsDir() {
+		return "", errors.Errorf("%q is a directory", p)
+	}
+
+	if client == nil || client.driver == nil || !client.driver.isRemote() {
+		return abs, nil
+	}
+
+	return s.uploadFile(abs)
+}
+
+// uploadFile uploads the file to the remote browser driver and returns the
+// file's path on the remote machine.
+func (s *Session) uploadFile(p string) (string, error) {
+	data, err := zipFile(p)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to zip file")
+	}
+
+	payload := struct {
+		File string `json:"file"`
+	}{base64.StdEncoding.EncodeToString(data)}
+
+	res, err := s.api.executeRequest(
+		http.MethodPost, fmt.Sprintf("/session/%s/se/file", s.id), payload,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to upload file")
+	}
+
+	v, ok := res.Value.(string)
+	if !ok || v == "" {
+		return "", errors.New("failed to get uploaded file's path")
+	}
+
+	return v, nil
+}
+
+func zipFile(p string) ([]byte, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open file")
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+
+	w, err := zw.Create(filepath.Base(p))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create zip entry")
+	}
+
+	_, err = io.Copy(w, f)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to write zip entry")
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to close zip writer")
+	}
+
+	return buf.Bytes(), nil
+}
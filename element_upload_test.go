@@ -0,0 +1,98 @@
+package selenium
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestZipFile(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "invoice.txt")
+
+	err := os.WriteFile(p, []byte("total: 42"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := zipFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(zr.File) != 1 || zr.File[0].Name != "invoice.txt" {
+		t.Fatalf("unexpected zip entries %v", zr.File)
+	}
+
+	f, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "total: 42" {
+		t.Errorf("unexpected content %q", content)
+	}
+
+	_, err = zipFile(filepath.Join(t.TempDir(), "missing.txt"))
+	if err == nil {
+		t.Error("missing file is zipped")
+	}
+}
+
+func TestUploadFileToRemoteDriver(t *testing.T) {
+	setTestConfig(t).WebDriver.Remote = true
+	setFakeClient(t, nil)
+
+	p := filepath.Join(t.TempDir(), "invoice.txt")
+
+	err := os.WriteFile(p, []byte("total: 42"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/se/file": {value: "/remote/invoice.txt"},
+	})
+
+	got, err := s.prepareFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != "/remote/invoice.txt" {
+		t.Errorf("expected remote path, got %q", got)
+	}
+
+	if n := requests(s, "POST /session/fake/se/file"); n != 1 {
+		t.Errorf("expected file to be uploaded once, got %d", n)
+	}
+
+	config.WebDriver.Remote = false
+	client.driver.remoteURL = "http://localhost:4444"
+
+	got, err = s.prepareFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != p {
+		t.Errorf("expected local path %q, got %q", p, got)
+	}
+
+	if n := requests(s, "POST /session/fake/se/file"); n != 1 {
+		t.Errorf("file is uploaded to local driver")
+	}
+}
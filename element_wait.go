@@ -1,16 +1,22 @@
 package selenium
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
-	"github.com/aleksslitvinovs/go-selenium/logger"
+	"github.com/aleksslitvinovs/go-selenium/types"
 	"github.com/pkg/errors"
 )
 
 // Waiter is a helper struct to wait for an element to be present, visible, etc.
 type Waiter struct {
-	e       *Element
-	timeout time.Duration
+	e             *Element
+	timeout       time.Duration
+	pollInterval  time.Duration
+	ignoredErrors []error
+	message       string
 }
 
 // WaitFor creates an instance of *Waiter with the provided timeout duration.
@@ -21,6 +27,117 @@ func (e *Element) WaitFor(timeout time.Duration) *Waiter {
 	}
 }
 
+// WithPollInterval sets the time between condition checks. Defaults to
+// element.poll_interval from the config.
+func (w *Waiter) WithPollInterval(interval time.Duration) *Waiter {
+	w.pollInterval = interval
+
+	return w
+}
+
+// Ignoring sets errors that do not stop the wait if they are raised while
+// checking the condition, e.g., types.ErrStaleElementReference.
+func (w *Waiter) Ignoring(errs ...error) *Waiter {
+	w.ignoredErrors = append(w.ignoredErrors, errs...)
+
+	return w
+}
+
+// WithMessage sets the error message that is used when the timeout expires.
+func (w *Waiter) WithMessage(message string) *Waiter {
+	w.message = message
+
+	return w
+}
+
+// Until waits until the given condition returns true.
+func (w *Waiter) Until(condition func(e *Element) bool) *Element {
+	return w.until("satisfying the condition", func() (bool, error) {
+		return condition(w.e), nil
+	})
+}
+
+// UntilTextIs waits until the element's text is equal to the given.
+func (w *Waiter) UntilTextIs(text string) *Element {
+	return w.until(
+		fmt.Sprintf("showing text %q", text),
+		func() (bool, error) { return w.e.GetText() == text, nil },
+	)
+}
+
+// UntilTextContains waits until the element's text contains the given.
+func (w *Waiter) UntilTextContains(text string) *Element {
+	return w.until(
+		fmt.Sprintf("showing text containing %q", text),
+		func() (bool, error) {
+			return strings.Contains(w.e.GetText(), text), nil
+		},
+	)
+}
+
+// UntilTextMatches waits until the element's text matches the given regular
+// expression.
+func (w *Waiter) UntilTextMatches(re *regexp.Regexp) *Element {
+	return w.until(
+		fmt.Sprintf("showing text matching %q", re),
+		func() (bool, error) { return re.MatchString(w.e.GetText()), nil },
+	)
+}
+
+// UntilAttributeIs waits until the element's attribute is equal to the given
+// value.
+func (w *Waiter) UntilAttributeIs(attribute, value string) *Element {
+	return w.until(
+		fmt.Sprintf("having %q attribute equal to %q", attribute, value),
+		func() (bool, error) {
+			return w.e.GetAttribute(attribute) == value, nil
+		},
+	)
+}
+
+// UntilCSSValueIs waits until the computed value of the element's CSS property
+// is equal to the given value.
+func (w *Waiter) UntilCSSValueIs(property, value string) *Element {
+	return w.until(
+		fmt.Sprintf("having %q CSS value equal to %q", property, value),
+		func() (bool, error) {
+			return w.e.GetCSSValue(property) == value, nil
+		},
+	)
+}
+
+// UntilIsClickable waits until the element is visible, enabled and is not
+// obscured by other elements. As a side effect, the element is scrolled to the
+// center of the viewport on each check, so that elements outside the viewport
+// are not reported as obscured.
+func (w *Waiter) UntilIsClickable() *Element {
+	return w.until("clickable", func() (bool, error) {
+		if !w.e.IsVisible() || !w.e.IsEnabled() {
+			return false, nil
+		}
+
+		return !w.e.isObscured(), nil
+	})
+}
+
+// UntilStable waits until the element's position and size do not change
+// between two consecutive checks, e.g., when the element is animated.
+func (w *Waiter) UntilStable() *Element {
+	var previous *Rect
+
+	return w.until("stable", func() (bool, error) {
+		rect, err := w.e.getRect()
+		if err != nil {
+			return false, err
+		}
+
+		stable := previous != nil && *previous == rect
+		previous = &rect
+
+		return stable, nil
+	})
+}
+
 // UntilIsPresent waits until the element is present.
 func (w *Waiter) UntilIsPresent() *Element {
 	return waitPresent(w, true)
@@ -61,123 +178,117 @@ func (w *Waiter) UntilIsNotSelected() *Element {
 	return waitCondition(w, w.e.isSelected, false, "not selected")
 }
 
-func waitCondition(
-	w *Waiter,
-	condition func() (*response, error),
-	expected bool,
-	conditionName string,
+func (w *Waiter) options() *WaitOptions {
+	return (&WaitOptions{
+		Timeout:       w.timeout,
+		PollInterval:  w.pollInterval,
+		IgnoredErrors: w.ignoredErrors,
+		Message:       w.message,
+	}).withDefaults()
+}
+
+// until waits until the given condition returns true. If the element becomes
+// stale, it is located again during the next check.
+func (w *Waiter) until(
+	conditionName string, condition func() (bool, error),
 ) *Element {
 	startTime := time.Now()
-	endTime := startTime.Add(w.timeout)
-
-	intialSettings := *w.e.settings
-
-	w.e.settings.IgnoreNotFound = true
-
-	defer func() {
-		w.e.settings = &intialSettings
-	}()
-
-	for {
-		if endTime.Before(time.Now()) {
-			handleError(
-				nil,
-				errors.Errorf(
-					"Element %q is not %s after %s (time elapsed %dms)",
-					w.e.Selector,
-					conditionName,
-					w.timeout,
-					time.Since(startTime).Milliseconds(),
-				),
-			)
-
-			return w.e
-		}
+	opts := w.options()
+
+	// Stale element is located again, therefore, the error can be ignored.
+	opts.IgnoredErrors = append(opts.IgnoredErrors, w.e.staleErrors()...)
+
+	err := poll(opts, w.e.relocating(w.e.session.checking(condition)))
+	if err == nil {
+		w.e.session.infof(
+			"Element %q is %s after %s (time elapsed %dms)",
+			w.e.Selector,
+			conditionName,
+			w.timeout,
+			time.Since(startTime).Milliseconds(),
+		)
+
+		return w.e
+	}
 
-		res, err := condition()
-		if err != nil {
-			handleError(res, err)
+	if !isWaitTimeout(err) {
+		w.e.session.handleError(nil, err)
 
-			return w.e
-		}
+		return w.e
+	}
 
-		if res.Value != nil {
-			if v, ok := res.Value.(bool); ok {
-				if v == expected {
-					logger.Infof(
-						"Element %q is %s after %s (time elapsed %dms)",
-						w.e.Selector,
-						conditionName,
-						w.timeout,
-						time.Since(startTime).Milliseconds(),
-					)
-
-					return w.e
-				}
-			}
+	w.e.session.handleError(
+		nil,
+		opts.timeoutError(
+			err,
+			"Element %q is not %s after %s (time elapsed %dms)",
+			w.e.Selector,
+			conditionName,
+			w.timeout,
+			time.Since(startTime).Milliseconds(),
+		),
+	)
+
+	return w.e
+}
+
+// relocating wraps the condition so that the element is located again during
+// the next check if it becomes stale. Elements returned by scripts are not
+// located again.
+func (e *Element) relocating(
+	condition func() (bool, error),
+) func() (bool, error) {
+	return func() (bool, error) {
+		ok, err := callCondition(condition)
+		if err != nil && !e.scripted &&
+			errors.Is(err, types.ErrStaleElementReference) {
+			e.id = ""
 		}
 
-		time.Sleep(w.e.settings.PollInterval.Duration)
+		return ok, err
 	}
 }
 
-func waitPresent(
+// waitCondition waits until the element's state, e.g., visibility, is equal
+// to the expected. The element is located first if it has not been yet.
+func waitCondition(
 	w *Waiter,
-	bePresent bool,
+	condition func() (*response, error),
+	expected bool,
+	conditionName string,
 ) *Element {
-	startTime := time.Now()
-	endTime := startTime.Add(w.timeout)
+	return w.until(conditionName, func() (bool, error) {
+		if w.e.id == "" {
+			ok, err := w.e.lookup()
+			if err != nil || !ok {
+				return false, err
+			}
+		}
 
-	intialSettings := *w.e.settings
+		res, err := condition()
+		if err != nil {
+			if errRes := res.getErrorReponse(); errRes != nil {
+				return false, errRes
+			}
 
-	w.e.settings.IgnoreNotFound = true
+			return false, err
+		}
 
-	defer func() {
-		w.e.settings = &intialSettings
-	}()
+		v, ok := res.Value.(bool)
 
+		return ok && v == expected, nil
+	})
+}
+
+func waitPresent(w *Waiter, bePresent bool) *Element {
 	conditionName := "present"
 	if !bePresent {
 		conditionName = "not present"
 	}
 
-	for {
-		if endTime.Before(time.Now()) {
-			handleError(
-				nil,
-				errors.Errorf(
-					"Element %q is not %s after %s (time elapsed %s)",
-					w.e.Selector,
-					conditionName,
-					w.timeout,
-					time.Since(startTime),
-				),
-			)
-
-			return w.e
-		}
+	return w.until(conditionName, func() (bool, error) {
+		ok, err := w.e.lookup()
 
-		id, err := w.e.findElement()
-		if err != nil {
-			handleError(nil, err)
-
-			return w.e
-		}
-
-		if id != "" && bePresent || id == "" && !bePresent {
-			logger.Infof(
-				"Element %q is %s after %s (time elapsed %dms)",
-				w.e.Selector,
-				conditionName,
-				w.timeout,
-				time.Since(startTime).Milliseconds(),
-			)
-
-			w.e.id = id
-
-			return w.e
-		}
-
-		time.Sleep(w.e.settings.PollInterval.Duration)
-	}
+		return ok == bePresent, err
+	})
 }
@@ -0,0 +1,181 @@
+package selenium
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/types"
+)
+
+func TestUntilStableFailsWithoutRect(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/element": fakeElement,
+		"GET /session/fake/element/el/rect": {
+			status: http.StatusInternalServerError,
+			value: map[string]string{
+				"error":   "javascript error",
+				"message": "element is not rendered",
+			},
+		},
+	})
+
+	s.NewElement("#box").WaitFor(0).UntilStable()
+
+	for _, e := range s.logEntries() {
+		if strings.Contains(e.message, "is stable") {
+			t.Errorf("wait passed without the element's rect: %s", e.message)
+		}
+	}
+
+	if failures := s.Failures(); len(failures) != 1 {
+		t.Errorf("expected 1 failure, got %v", failures)
+	}
+}
+
+func TestUntilStableRelocatesStaleElement(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/element": fakeElement,
+		"GET /session/fake/element/old/rect": {
+			status: http.StatusNotFound,
+			value: map[string]string{
+				"error":   "stale element reference",
+				"message": "element is not attached to the page document",
+			},
+		},
+		"GET /session/fake/element/el/rect": {
+			value: map[string]float64{"x": 1, "y": 2, "width": 3, "height": 4},
+		},
+	})
+
+	e := s.NewElement("#box")
+	e.id = "old"
+
+	e.WaitFor(time.Second).UntilStable()
+
+	if e.id != "el" {
+		t.Errorf("stale element is not located again, ID %q", e.id)
+	}
+
+	if failures := s.Failures(); len(failures) != 0 {
+		t.Errorf("unexpected failures %v", failures)
+	}
+}
+
+func TestUntilStable(t *testing.T) {
+	setTestConfig(t)
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/element": fakeElement,
+		"GET /session/fake/element/el/rect": {
+			value: map[string]float64{"x": 1, "y": 2, "width": 3, "height": 4},
+		},
+	})
+
+	s.NewElement("#box").WaitFor(0).UntilStable()
+
+	entries := s.logEntries()
+	if len(entries) != 1 || !strings.Contains(entries[0].message, "stable") {
+		t.Errorf("expected the element to be stable, log: %v", entries)
+	}
+}
+
+func TestUntilIsVisibleIgnoresErrors(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/element": fakeElement,
+		"GET /session/fake/element/el/displayed": {
+			status: http.StatusInternalServerError,
+			value: map[string]string{
+				"error":   "javascript error",
+				"message": "document is not ready",
+			},
+		},
+	})
+
+	startTime := time.Now()
+
+	s.NewElement("#box").
+		WaitFor(50 * time.Millisecond).
+		Ignoring(types.ErrJavaScriptError).
+		UntilIsVisible()
+
+	if elapsed := time.Since(startTime); elapsed < 50*time.Millisecond {
+		t.Errorf("wait stopped after %s", elapsed)
+	}
+
+	if n := requests(s, "GET /session/fake/element/el/displayed"); n < 2 {
+		t.Errorf("expected visibility to be checked repeatedly, got %d", n)
+	}
+
+	failures := s.Failures()
+	if len(failures) != 1 ||
+		!strings.Contains(failures[0].Message, "is not visible after") {
+		t.Errorf("expected wait to time out, got %v", failures)
+	}
+}
+
+func TestUntilIsVisible(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/element":             fakeElement,
+		"GET /session/fake/element/el/displayed": {value: true},
+	})
+
+	e := s.NewElement("#box").WaitFor(time.Second).UntilIsVisible()
+
+	visible := false
+
+	for _, entry := range s.logEntries() {
+		visible = visible || strings.Contains(entry.message, "is visible")
+	}
+
+	if !visible || e.id != "el" || len(s.Failures()) != 0 {
+		t.Errorf("expected the element to be visible, log: %v", s.logEntries())
+	}
+}
+
+func TestUntilIsPresent(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/element": {
+			status: http.StatusNotFound,
+			value: map[string]string{
+				"error":   "no such element",
+				"message": "unable to locate element",
+			},
+		},
+	})
+
+	s.NewElement("#box").
+		WaitFor(50 * time.Millisecond).
+		WithPollInterval(5 * time.Millisecond).
+		UntilIsNotPresent()
+
+	if failures := s.Failures(); len(failures) != 0 {
+		t.Fatalf("unexpected failures %v", failures)
+	}
+
+	s.NewElement("#box").
+		WaitFor(50 * time.Millisecond).
+		WithPollInterval(5 * time.Millisecond).
+		UntilIsPresent()
+
+	failures := s.Failures()
+	if len(failures) != 1 ||
+		!strings.Contains(failures[0].Message, "is not present after") {
+		t.Errorf("expected wait to time out, got %v", failures)
+	}
+
+	if n := requests(s, "POST /session/fake/element"); n < 3 {
+		t.Errorf("expected presence to be checked repeatedly, got %d", n)
+	}
+}
@@ -3,6 +3,7 @@ package selenium
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/aleksslitvinovs/go-selenium/types"
 	"github.com/pkg/errors"
@@ -12,9 +13,10 @@ import (
 type Elements struct {
 	E
 
-	elements []*Element
+	filters []func(*Element) bool
 
 	session  *Session
+	parent   *Element
 	settings *elementSettings
 	api      *apiClient
 }
@@ -37,29 +39,125 @@ func (s *Session) NewElements(e interface{}) *Elements {
 
 // Size returns the number of elements.
 func (ee *Elements) Size() int {
-	ee.setElementsID()
-
-	return len(ee.elements)
+	return len(ee.Elements())
 }
 
-// Elements returns the list elements.
+// Elements returns the list elements. Elements are located every time the
+// method is called.
 func (ee *Elements) Elements() []*Element {
-	return ee.elements
+	var elements []*Element
+
+	err := ee.retry(func() (bool, error) {
+		var err error
+
+		elements, err = ee.resolve()
+
+		return err == nil, err
+	})
+	if err != nil {
+		ee.session.handleError(
+			nil,
+			errors.Wrapf(err, "failed to find elements %q", ee.Selector),
+		)
+
+		return []*Element{}
+	}
+
+	return elements
+}
+
+// Texts returns the text of every element.
+func (ee *Elements) Texts() []string {
+	var texts []string
+
+	err := ee.retry(func() (bool, error) {
+		var err error
+
+		texts, err = ee.texts()
+
+		return err == nil, err
+	})
+	if err != nil {
+		ee.session.handleError(
+			nil,
+			errors.Wrapf(
+				err, "failed to get texts of elements %q", ee.Selector,
+			),
+		)
+
+		return []string{}
+	}
+
+	return texts
+}
+
+// Filter returns a new Elements that contains only elements for which the
+// given function returns true. The function is called every time the elements
+// are located.
+func (ee *Elements) Filter(fn func(e *Element) bool) *Elements {
+	filtered := *ee
+
+	filtered.filters = make([]func(*Element) bool, 0, len(ee.filters)+1)
+	filtered.filters = append(filtered.filters, ee.filters...)
+	filtered.filters = append(filtered.filters, fn)
+
+	return &filtered
+}
+
+// First returns the first element of the collection.
+func (ee *Elements) First() *Element {
+	return ee.Nth(0)
+}
+
+// Nth returns the element at the given zero-based index of the collection.
+// Similarly to NewElement, the element is located when it is used for the
+// first time and is located again if it becomes stale.
+func (ee *Elements) Nth(index int) *Element {
+	if index < 0 {
+		ee.session.handleError(
+			nil, errors.Errorf("invalid element index %d", index),
+		)
+
+		return nil
+	}
+
+	return ee.newItem(index, "")
+}
+
+// FindByText returns the first element whose text is equal to the given.
+func (ee *Elements) FindByText(text string) *Element {
+	return ee.Filter(func(e *Element) bool {
+		return e.GetText() == text
+	}).First()
+}
+
+// newChildElements returns a new Elements that are located within e.
+func (e *Element) newChildElements(sel interface{}) *Elements {
+	children := e.session.NewElements(sel)
+	children.parent = e
+
+	return children
 }
 
 func (ee *Elements) findElements() ([]string, error) {
-	res, err := ee.api.executeRequest(
-		http.MethodPost,
-		fmt.Sprintf("/session/%s/elements", ee.session.id),
-		ee.E,
-	)
+	route := fmt.Sprintf("/session/%s/elements", ee.session.id)
+
+	if ee.parent != nil {
+		ee.parent.setElementID()
+
+		route = fmt.Sprintf(
+			"/session/%s/element/%s/elements", ee.session.id, ee.parent.id,
+		)
+	}
+
+	res, err := ee.api.executeRequest(http.MethodPost, route, ee.E)
 	if err != nil {
 		errRes := res.getErrorReponse()
 		if errRes == nil {
 			return []string{}, errors.Wrap(err, "failed to find element")
 		}
 
-		if errors.As(errRes, &types.ErrNoSuchElement) &&
+		if errors.Is(errRes, types.ErrNoSuchElement) &&
 			ee.settings.IgnoreNotFound {
 			return []string{}, nil
 		}
@@ -92,20 +190,107 @@ func (ee *Elements) findElements() ([]string, error) {
 	return ids, nil
 }
 
-func (ee *Elements) setElementsID() {
-	if len(ee.elements) != 0 {
-		return
+// resolve locates the elements and applies the filters. Returned elements are
+// located again via the collection if they become stale.
+func (ee *Elements) resolve() ([]*Element, error) {
+	ids, err := ee.findElements()
+	if err != nil {
+		return nil, err
 	}
 
-	ids, err := ee.findElements()
+	unfiltered := *ee
+	unfiltered.filters = nil
+
+	elements := make([]*Element, 0, len(ids))
+
+	for i, id := range ids {
+		e := unfiltered.newItem(i, id)
+
+		ok, err := ee.matches(e)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			elements = append(elements, e)
+		}
+	}
+
+	for i, e := range elements {
+		e.collection = ee
+		e.index = i
+	}
+
+	return elements, nil
+}
+
+func (ee *Elements) matches(e *Element) (bool, error) {
+	for _, filter := range ee.filters {
+		ok, err := callCondition(e.session.checking(func() (bool, error) {
+			return filter(e), nil
+		}))
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (ee *Elements) texts() ([]string, error) {
+	elements, err := ee.resolve()
 	if err != nil {
-		handleError(nil, err)
+		return nil, err
 	}
 
-	for _, id := range ids {
-		e := ee.session.NewElement(id)
-		e.id = id
+	texts := make([]string, 0, len(elements))
+
+	for _, e := range elements {
+		var text string
+
+		_, err := callCondition(e.session.checking(func() (bool, error) {
+			text = e.GetText()
+
+			return true, nil
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		texts = append(texts, text)
+	}
+
+	return texts, nil
+}
+
+// retry calls the condition until it returns true. Stale elements are located
+// again.
+func (ee *Elements) retry(condition func() (bool, error)) error {
+	return poll(
+		ee.retryOptions(ee.settings.RetryTimeout.Duration),
+		ee.session.checking(condition),
+	)
+}
+
+// retryOptions returns options that are used to retry collection's
+// assertions.
+func (ee *Elements) retryOptions(timeout time.Duration) *WaitOptions {
+	return (&WaitOptions{
+		Timeout:       timeout,
+		PollInterval:  ee.settings.PollInterval.Duration,
+		IgnoredErrors: []error{types.ErrStaleElementReference},
+	}).withDefaults()
+}
 
-		ee.elements = append(ee.elements, e)
+// newItem returns the element at the given index of the collection.
+func (ee *Elements) newItem(index int, id string) *Element {
+	return &Element{
+		E:          ee.E,
+		id:         id,
+		collection: ee,
+		index:      index,
+		session:    ee.session,
+		settings:   ee.settings,
+		api:        ee.api,
 	}
 }
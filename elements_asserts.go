@@ -0,0 +1,206 @@
+package selenium
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ElementsAsserter is a helper struct to assert the number of elements in the
+// collection and their texts. Assertions are retried until they pass or the
+// timeout expires.
+type ElementsAsserter struct {
+	ee      *Elements
+	timeout time.Duration
+}
+
+// TextsValuer is a helper struct to compare the texts of all elements in the
+// collection with the expected. The texts are retrieved again until the
+// comparison passes or the timeout expires.
+type TextsValuer struct {
+	ee      *Elements
+	actual  []string
+	isEqual bool
+	timeout time.Duration
+}
+
+// ShouldHave returns an ElementsAsserter for the given collection. The
+// returned ElementsAsserter can be used to assert the number of elements and
+// their texts.
+func (ee *Elements) ShouldHave() *ElementsAsserter {
+	return &ElementsAsserter{
+		ee:      ee,
+		timeout: ee.settings.RetryTimeout.Duration,
+	}
+}
+
+// Within overrides the time during which the assertions are retried. Defaults
+// to element.retry_timeout from the config.
+func (a *ElementsAsserter) Within(timeout time.Duration) *ElementsAsserter {
+	a.timeout = timeout
+
+	return a
+}
+
+// Count asserts that the collection contains exactly the given number of
+// elements.
+func (a *ElementsAsserter) Count(count int) {
+	a.count().EqualTo(strconv.Itoa(count))
+}
+
+// CountAtLeast asserts that the collection contains at least the given number
+// of elements.
+func (a *ElementsAsserter) CountAtLeast(count int) {
+	a.count().Satisfy(atLeast(float64(count)))
+}
+
+// AnyText allows asserting that the text of at least one element in the
+// collection satisfies the assertion. If Not is used, none of the texts must
+// satisfy it.
+func (a *ElementsAsserter) AnyText() *Valuer {
+	v := a.newValuer("text")
+	v.subject = "any of elements'"
+	v.getAny = a.ee.texts
+
+	return v
+}
+
+// Texts allows asserting the texts of all elements in the collection.
+func (a *ElementsAsserter) Texts() *TextsValuer {
+	return &TextsValuer{
+		ee:      a.ee,
+		isEqual: true,
+		timeout: a.timeout,
+	}
+}
+
+func (a *ElementsAsserter) count() *Valuer {
+	v := a.newValuer("count")
+	v.get = func() (string, error) {
+		elements, err := a.ee.resolve()
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.Itoa(len(elements)), nil
+	}
+
+	return v
+}
+
+func (a *ElementsAsserter) newValuer(property string) *Valuer {
+	return &Valuer{
+		ee:       a.ee,
+		subject:  "elements'",
+		property: property,
+		isEqual:  true,
+		timeout:  a.timeout,
+	}
+}
+
+// Not negates the following assertion.
+func (v *TextsValuer) Not() *TextsValuer {
+	v.isEqual = false
+
+	return v
+}
+
+// Within overrides the time during which the assertion is retried. Defaults to
+// element.retry_timeout from the config.
+func (v *TextsValuer) Within(timeout time.Duration) *TextsValuer {
+	v.timeout = timeout
+
+	return v
+}
+
+// EqualTo asserts that the texts of the elements are equal to the given, i.e.,
+// the number of elements and their order match.
+func (v *TextsValuer) EqualTo(expected []string) {
+	v.compare(
+		fmt.Sprintf("been equal to %q", expected),
+		fmt.Sprintf("equal %q", expected),
+		func(actual []string) bool {
+			if len(actual) != len(expected) {
+				return false
+			}
+
+			for i := range actual {
+				if actual[i] != expected[i] {
+					return false
+				}
+			}
+
+			return true
+		},
+	)
+}
+
+// ContainInOrder asserts that the texts of the elements contain the given in
+// the same order. Other texts may appear between the given.
+func (v *TextsValuer) ContainInOrder(expected ...string) {
+	v.compare(
+		fmt.Sprintf("contained %q in order", expected),
+		fmt.Sprintf("contain %q in order", expected),
+		func(actual []string) bool {
+			var i int
+
+			for _, text := range actual {
+				if i < len(expected) && text == expected[i] {
+					i++
+				}
+			}
+
+			return i == len(expected)
+		},
+	)
+}
+
+func (v *TextsValuer) compare(
+	past, present string, match func(actual []string) bool,
+) {
+	err := poll(v.ee.retryOptions(v.timeout), func() (bool, error) {
+		actual, err := v.ee.texts()
+		if err != nil {
+			return false, err
+		}
+
+		v.actual = actual
+
+		return xnor(match(actual), v.isEqual), nil
+	})
+
+	not := struct{ past, present string }{"", "do"}
+	if !v.isEqual {
+		not.past, not.present = "not ", "do not"
+	}
+
+	if err == nil {
+		v.ee.session.pass("elements' texts %s %s", not.present, present)
+
+		return
+	}
+
+	f := &AssertionFailure{
+		Selector: v.ee.Selector,
+		Property: "texts",
+		Expected: not.past + present,
+	}
+
+	if !isWaitTimeout(err) {
+		f.Message = errors.Wrap(err, "failed to assert elements' texts").Error()
+
+		v.ee.session.fail(f)
+
+		return
+	}
+
+	f.Actual = fmt.Sprintf("%q", v.actual)
+	f.Message = fmt.Sprintf(
+		"elements' texts should have %s%s, actual values %s",
+		not.past, past, f.Actual,
+	)
+
+	v.ee.session.fail(f)
+}
@@ -0,0 +1,123 @@
+package selenium
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aleksslitvinovs/go-selenium/types"
+	"github.com/pkg/errors"
+)
+
+// staleElement is the response of the fake driver to a command of an element
+// that is no longer attached to the page.
+var staleElement = fakeResponse{
+	status: http.StatusNotFound,
+	value: map[string]string{
+		"error":   "stale element reference",
+		"message": "element is not attached to the page document",
+	},
+}
+
+// collectionRoutes describe the collection of "a" and "b" elements with text
+// "One" and "Two". Text of "a" is requested while the page is re-rendered,
+// therefore, the first request responds with stale element reference.
+func collectionRoutes() map[string]fakeResponse {
+	return map[string]fakeResponse{
+		"POST /session/fake/elements": {
+			value: []map[string]string{
+				{webElementID: "a"},
+				{webElementID: "b"},
+			},
+		},
+		"GET /session/fake/element/a/text": {
+			status: staleElement.status,
+			value:  staleElement.value,
+			then:   &fakeResponse{value: "One"},
+		},
+		"GET /session/fake/element/b/text": {value: "Two"},
+	}
+}
+
+func TestElementsTextsResolvesStaleElements(t *testing.T) {
+	setTestConfig(t)
+
+	s := newFakeSession(t, collectionRoutes())
+
+	texts := s.NewElements(".item").Texts()
+	if !equalStrings(texts, []string{"One", "Two"}) {
+		t.Errorf("unexpected texts %v", texts)
+	}
+
+	if n := requests(s, "POST /session/fake/elements"); n != 2 {
+		t.Errorf("expected elements to be located twice, got %d", n)
+	}
+}
+
+func TestElementsFilterResolvesStaleElements(t *testing.T) {
+	setTestConfig(t)
+
+	s := newFakeSession(t, collectionRoutes())
+
+	elements := s.NewElements(".item").Filter(func(e *Element) bool {
+		return e.GetText() == "One"
+	}).Elements()
+
+	if len(elements) != 1 || elements[0].id != "a" {
+		t.Fatalf("unexpected elements %v", elements)
+	}
+
+	if elements[0].index != 0 {
+		t.Errorf("element is not indexed within the filtered collection")
+	}
+
+	if n := requests(s, "POST /session/fake/elements"); n != 2 {
+		t.Errorf("expected elements to be located twice, got %d", n)
+	}
+}
+
+func TestElementsNthResolvesStaleElement(t *testing.T) {
+	setTestConfig(t)
+
+	routes := collectionRoutes()
+	routes["GET /session/fake/element/b/text"] = fakeResponse{
+		status: staleElement.status,
+		value:  staleElement.value,
+		then:   &fakeResponse{value: "Two"},
+	}
+
+	s := newFakeSession(t, routes)
+
+	e := s.NewElements(".item").Nth(1)
+	e.ShouldHave().Text().EqualTo("Two")
+
+	if e.id != "b" {
+		t.Errorf("expected element b, got %q", e.id)
+	}
+
+	if n := requests(s, "POST /session/fake/elements"); n != 2 {
+		t.Errorf("expected elements to be located twice, got %d", n)
+	}
+}
+
+func TestFindInCollection(t *testing.T) {
+	setTestConfig(t)
+
+	s := newFakeSession(t, collectionRoutes())
+
+	id, err := s.NewElements(".item").Nth(1).findInCollection()
+	if err != nil || id != "b" {
+		t.Errorf("findInCollection() = %q, %v, want b", id, err)
+	}
+
+	e := s.NewElements(".item").Nth(2)
+
+	_, err = e.findInCollection()
+	if !errors.Is(err, types.ErrNoSuchElement) {
+		t.Errorf("expected ErrNoSuchElement, got %v", err)
+	}
+
+	ok, err := e.lookup()
+	if ok || err != nil {
+		t.Errorf("lookup() = %t, %v, want missing element", ok, err)
+	}
+}
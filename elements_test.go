@@ -7,12 +7,21 @@ import (
 
 	"github.com/aleksslitvinovs/go-selenium"
 	"github.com/aleksslitvinovs/go-selenium/keys"
+	"github.com/aleksslitvinovs/go-selenium/seleniumtest"
 )
 
+func TestMain(m *testing.M) {
+	seleniumtest.Main(m)
+}
+
 func TestElements(t *testing.T) {
-	selenium.SetTest(MultipleElementsTest)
+	selenium.SetTestWithOptions(
+		MultipleElementsTest,
+		selenium.Name("multiple_elements"),
+		selenium.Tags("smoke"),
+	)
 
-	selenium.Run()
+	seleniumtest.RunT(t)
 }
 
 func MultipleElementsTest(s *selenium.Session) {
@@ -1,30 +1,96 @@
 package selenium
 
 import (
+	"fmt"
+
 	"github.com/aleksslitvinovs/go-selenium/logger"
 )
 
 func handleError(res *response, err error) {
+	raiseError(res, err, logger.Error)
+}
+
+// raiseError logs the error via log and panics with it, unless soft
+// assertions are used.
+func raiseError(res *response, err error, log func(msg ...interface{})) {
 	if res == nil {
-		logger.Error(err.Error())
+		log(err.Error())
 
 		if config.SoftAsserts {
 			return
 		}
 
-		panic(err.Error())
+		panic(err)
 	}
 
 	errRes := res.getErrorReponse()
 	if errRes != nil {
-		logger.Error(errRes)
+		log(errRes)
 
 		if config.SoftAsserts {
 			return
 		}
 
-		panic(errRes.Error())
+		panic(errRes)
+	}
+
+	panic(err)
+}
+
+// handleError records the error of the session's action or wait as the
+// session's failure, so that it is logged, reported and fails the test even if
+// soft assertions are used.
+//
+// Errors that occur while conditions are checked are only logged to the
+// session's log or raised, as the condition is either checked again or its
+// caller handles the error.
+func (s *Session) handleError(res *response, err error) {
+	if s == nil {
+		handleError(res, err)
+
+		return
+	}
+
+	if s.isChecking() {
+		raiseError(res, err, func(msg ...interface{}) {
+			s.errorf("%s", fmt.Sprint(msg...))
+		})
+
+		return
 	}
 
-	panic(err.Error())
+	msg := err.Error()
+	if errRes := res.getErrorReponse(); errRes != nil {
+		msg = fmt.Sprintf("%s: %s", errRes.Err, errRes.Message)
+	}
+
+	s.fail(newFailure(msg))
+}
+
+// checking wraps the condition of a wait or a retried assertion, so that
+// errors that the session handles while the condition is checked are raised
+// or logged as they are instead of being recorded as failures.
+func (s *Session) checking(
+	condition func() (bool, error),
+) func() (bool, error) {
+	return func() (bool, error) {
+		s.mu.Lock()
+		s.checks++
+		s.mu.Unlock()
+
+		defer func() {
+			s.mu.Lock()
+			s.checks--
+			s.mu.Unlock()
+		}()
+
+		return condition()
+	}
+}
+
+func (s *Session) isChecking() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.checks > 0
 }
@@ -0,0 +1,99 @@
+package selenium
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSoftActionErrorIsRecorded(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, nil)
+
+	s.OpenURL("https://example.com")
+
+	failures := s.Failures()
+	if len(failures) != 1 {
+		t.Fatalf("expected 1 failure, got %d", len(failures))
+	}
+
+	if !strings.Contains(failures[0].Message, "unknown command") {
+		t.Errorf("unexpected failure message %q", failures[0].Message)
+	}
+
+	if failures[0].File == "" {
+		t.Error("failure is not located at the caller")
+	}
+}
+
+func TestHardActionErrorIsRaised(t *testing.T) {
+	setTestConfig(t)
+
+	s := newFakeSession(t, nil)
+
+	defer func() {
+		f, ok := recover().(*AssertionFailure)
+		if !ok {
+			t.Fatal("expected *AssertionFailure to be raised")
+		}
+
+		if !strings.Contains(f.Message, "unknown command") {
+			t.Errorf("unexpected failure message %q", f.Message)
+		}
+	}()
+
+	s.OpenURL("https://example.com")
+}
+
+func TestSoftWaitCheckErrorsAreNotRecorded(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, nil)
+
+	checks := 0
+
+	s.WaitUntil(func(s *Session) (bool, error) {
+		checks++
+
+		// Fails on every check, but the wait passes on the third one.
+		s.GetTitle()
+
+		return checks == 3, nil
+	}, &WaitOptions{Timeout: time.Second, PollInterval: time.Millisecond})
+
+	if failures := s.Failures(); len(failures) != 0 {
+		t.Errorf("expected no failures, got %v", failures)
+	}
+
+	logged := 0
+
+	for _, e := range s.logEntries() {
+		if strings.Contains(e.String(), "unknown command") {
+			logged++
+		}
+	}
+
+	if logged != 3 {
+		t.Errorf("expected 3 check errors in the session's log, got %d", logged)
+	}
+}
+
+func TestSoftWaitTimeoutIsRecorded(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, nil)
+
+	s.WaitUntil(func(s *Session) (bool, error) {
+		return false, nil
+	}, &WaitOptions{
+		Timeout:      10 * time.Millisecond,
+		PollInterval: time.Millisecond,
+		Message:      "menu is not open",
+	})
+
+	failures := s.Failures()
+	if len(failures) != 1 || failures[0].Message != "menu is not open" {
+		t.Errorf("expected timeout failure, got %v", failures)
+	}
+}
@@ -0,0 +1,180 @@
+package selenium
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+)
+
+// EventType describes what happened during the test run.
+type EventType string
+
+// Events that are sent to reporters.
+const (
+	RunStartEvent   EventType = "run_start"
+	TestStartEvent  EventType = "test_start"
+	StepEvent       EventType = "step"
+	AssertionEvent  EventType = "assertion"
+	ScreenshotEvent EventType = "screenshot"
+	RetryEvent      EventType = "retry"
+	TestEndEvent    EventType = "test_end"
+	RunEndEvent     EventType = "run_end"
+)
+
+// Test statuses that are used in events and reports.
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusFlaky   = "flaky"
+	StatusSkipped = "skipped"
+)
+
+// Event describes what happened during the test run. Fields that are not
+// relevant to the event's type are empty.
+type Event struct {
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+	// Test is the name of the test the event belongs to.
+	Test string `json:"test,omitempty"`
+	// Attempt is the number of the test's attempt, starting from 1.
+	Attempt   int    `json:"attempt,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	// Step is the path of the current step, e.g., "Log in > Submit".
+	Step string `json:"step,omitempty"`
+	// Status is the status of the test, run, step or assertion.
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Screenshot is the path of the screenshot file.
+	Screenshot string            `json:"screenshot,omitempty"`
+	Failure    *AssertionFailure `json:"failure,omitempty"`
+	// Artifacts are set for TestEndEvent if the last attempt failed and
+	// runner.artifacts is set.
+	Artifacts *Artifacts `json:"artifacts,omitempty"`
+	// Elapsed is the duration of the step, test or run in seconds.
+	Elapsed float64 `json:"elapsed,omitempty"`
+	// Result is set for TestEndEvent.
+	Result *TestResult `json:"-"`
+	// Results are set for RunEndEvent and contain all tests of the run.
+	Results []*TestResult `json:"-"`
+}
+
+// Reporter receives events of the test run, e.g., to write a report when the
+// run ends. Events are delivered one at a time, even if tests are run in
+// parallel.
+type Reporter interface {
+	HandleEvent(e *Event) error
+}
+
+// AddReporter adds the reporter that receives events of the test runs in
+// addition to reporters set in runner.reports.
+func AddReporter(rep Reporter) {
+	r.reporters = append(r.reporters, rep)
+}
+
+// eventBus delivers events to reporters of the current run.
+type eventBus struct {
+	mu        sync.Mutex
+	reporters []Reporter
+	started   bool
+	startTime time.Time
+}
+
+var events = &eventBus{}
+
+// startRun creates reporters and sends RunStartEvent. Console reporter is used
+// by Run, while go test prints its own output. Run is started only once until
+// it is ended.
+func (b *eventBus) startRun(console bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.started {
+		return
+	}
+
+	b.started = true
+	b.startTime = time.Now()
+	b.reporters = nil
+
+	if console {
+		b.reporters = append(b.reporters, &consoleReporter{})
+	}
+
+	b.reporters = append(b.reporters, newConfigReporters()...)
+	b.reporters = append(b.reporters, r.reporters...)
+
+	b.send(&Event{Type: RunStartEvent, Time: b.startTime})
+}
+
+// endRun sends RunEndEvent with the results of the given tests.
+func (b *eventBus) endRun(status string, tests []*test) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.started {
+		return
+	}
+
+	results := make([]*TestResult, 0, len(tests))
+
+	for _, t := range tests {
+		results = append(results, t.result())
+	}
+
+	b.send(&Event{
+		Type:    RunEndEvent,
+		Status:  status,
+		Elapsed: time.Since(b.startTime).Seconds(),
+		Results: results,
+	})
+
+	b.started = false
+	b.reporters = nil
+}
+
+// emit sends the event to reporters. Events are dropped if the run is not
+// started, e.g., when sessions are used without the runner.
+func (b *eventBus) emit(e *Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.started {
+		return
+	}
+
+	b.send(e)
+}
+
+func (b *eventBus) send(e *Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	for _, rep := range b.reporters {
+		err := rep.HandleEvent(e)
+		if err != nil {
+			logger.Errorf("Failed to report %s event: %s", e.Type, err)
+		}
+	}
+}
+
+// emit sends the event on behalf of the session and its test.
+func (s *Session) emit(e *Event) {
+	e.Test = s.test
+	e.Attempt = s.attempt
+	e.SessionID = s.id
+
+	if e.Step == "" {
+		e.Step = s.stepPath()
+	}
+
+	events.emit(e)
+}
+
+// emit sends the event on behalf of the test.
+func (t *test) emit(e *Event) {
+	e.Test = t.name
+
+	events.emit(e)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/TylerBrock/colorjson"
 	"github.com/aleksslitvinovs/go-selenium/logger"
@@ -41,6 +42,11 @@ var f = &colorjson.Formatter{
 
 type apiClient struct {
 	baseURL string
+	// ctx cancels requests, e.g., when the test times out. If nil,
+	// requests are not cancelled.
+	ctx context.Context //nolint:containedctx
+	// commands records sent requests if set.
+	commands *commandLog
 }
 
 type response struct {
@@ -58,7 +64,7 @@ func (a *apiClient) executeRequest(
 ) (*response, error) {
 	res, reqErr := a.executeRequestRaw(method, route, payload)
 	if reqErr != nil {
-		if !errors.As(reqErr, &types.ErrFailedRequest) {
+		if !errors.Is(reqErr, types.ErrFailedRequest) {
 			return nil, errors.Wrap(reqErr, "failed to execute request")
 		}
 	}
@@ -88,7 +94,18 @@ func (a *apiClient) executeRequestCustom(
 ) (*response, error) {
 	res, err := a.executeRequestRaw(method, route, payload)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to execute request")
+		if !errors.Is(err, types.ErrFailedRequest) {
+			return nil, errors.Wrap(err, "failed to execute request")
+		}
+
+		// Error response is returned, so that the caller can check it.
+		var r response
+
+		if err := json.Unmarshal(res, &r); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal response")
+		}
+
+		return &r, err
 	}
 
 	err = json.Unmarshal(res, customResponse)
@@ -111,18 +128,43 @@ func (a *apiClient) executeRequestCustom(
 func (a *apiClient) executeRequestRaw(
 	method, route string, payload interface{},
 ) ([]byte, error) {
+	startTime := time.Now()
+
+	b, status, err := a.send(method, route, payload)
+
+	a.commands.record(webDriverCommand{
+		start:    startTime,
+		method:   method,
+		route:    route,
+		status:   status,
+		duration: time.Since(startTime),
+		err:      err,
+	})
+
+	return b, err
+}
+
+// send sends the request and returns the response body and status code.
+func (a *apiClient) send(
+	method, route string, payload interface{},
+) ([]byte, int, error) {
 	body, err := json.Marshal(payload)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to marshal payload")
+		return nil, 0, errors.Wrap(err, "failed to marshal payload")
 	}
 
 	url := a.baseURL + route
 
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	req, err := http.NewRequestWithContext(
-		context.Background(), method, url, bytes.NewBuffer(body),
+		ctx, method, url, bytes.NewBuffer(body),
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create request")
+		return nil, 0, errors.Wrap(err, "failed to create request")
 	}
 
 	if config.LogLevel == logger.DebugLvl {
@@ -134,14 +176,16 @@ func (a *apiClient) executeRequestRaw(
 
 	res, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to send request")
+		return nil, 0, errors.Wrap(err, "failed to send request")
 	}
 
 	defer res.Body.Close()
 
 	b, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to read response body")
+		return nil, res.StatusCode, errors.Wrap(
+			err, "failed to read response body",
+		)
 	}
 
 	var r response
@@ -149,7 +193,9 @@ func (a *apiClient) executeRequestRaw(
 	// Unmarshaling is needed to format errors
 	err = json.Unmarshal(b, &r)
 	if err != nil {
-		return []byte{}, errors.Wrap(err, "failed to unmarshal response")
+		return []byte{}, res.StatusCode, errors.Wrap(
+			err, "failed to unmarshal response",
+		)
 	}
 
 	if config.LogLevel == logger.DebugLvl {
@@ -160,10 +206,12 @@ func (a *apiClient) executeRequestRaw(
 	}
 
 	if getStatusClass(res.StatusCode) != classSuccessful {
-		return b, errors.Wrap(types.ErrFailedRequest, r.String())
+		return b, res.StatusCode, errors.Wrap(
+			types.ErrFailedRequest, r.String(),
+		)
 	}
 
-	return b, nil
+	return b, res.StatusCode, nil
 }
 
 func formatJSON(body []byte) string {
@@ -272,3 +320,9 @@ func (errRes *errorResponse) String() string {
 func (errRes *errorResponse) Error() string {
 	return errRes.Err
 }
+
+// Is allows comparing the error response with WebDriver errors defined in
+// types package, e.g., errors.Is(errRes, types.ErrNoSuchElement).
+func (errRes *errorResponse) Is(target error) bool {
+	return target != nil && errRes.Err == target.Error()
+}
@@ -0,0 +1,166 @@
+package selenium
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+	"github.com/aleksslitvinovs/go-selenium/selectors"
+	"github.com/aleksslitvinovs/go-selenium/types"
+)
+
+// fakeResponse is the value that the fake driver responds with after the
+// delay. Status defaults to 200. If then is set, it is used to respond to the
+// subsequent requests of the route.
+type fakeResponse struct {
+	status int
+	value  interface{}
+	delay  time.Duration
+	then   *fakeResponse
+}
+
+// setTestConfig sets the config with short waits for the test and restores
+// the previous config when the test ends.
+func setTestConfig(t *testing.T) *configParams {
+	t.Helper()
+
+	previous := config
+
+	t.Cleanup(func() {
+		config = previous
+	})
+
+	config = &configParams{
+		LogLevel: logger.ErrorLvl,
+		Runner:   &runnerSettings{ParallelRuns: 1},
+		Element: &elementSettings{
+			SelectorType: selectors.CSS,
+			RetryTimeout: types.Time{Duration: 200 * time.Millisecond},
+			PollInterval: types.Time{Duration: 10 * time.Millisecond},
+		},
+		WebDriver: &webDriverConfig{Browser: "chrome"},
+	}
+
+	return config
+}
+
+// newFakeDriver starts the fake driver and returns its URL. Routes are keyed
+// by the method and the path, e.g., "GET /session/fake/url". Unknown routes
+// respond with "unknown command".
+func newFakeDriver(t *testing.T, routes map[string]fakeResponse) string {
+	t.Helper()
+
+	var mu sync.Mutex
+
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			route := r.Method + " " + r.URL.Path
+
+			mu.Lock()
+			res, ok := routes[route]
+			if ok && res.then != nil {
+				routes[route] = *res.then
+			}
+			mu.Unlock()
+
+			if !ok {
+				res = fakeResponse{
+					status: http.StatusNotFound,
+					value: map[string]string{
+						"error":   "unknown command",
+						"message": r.URL.Path,
+					},
+				}
+			}
+
+			time.Sleep(res.delay)
+
+			if res.status == 0 {
+				res.status = http.StatusOK
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(res.status)
+
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"value": res.value,
+			})
+		},
+	))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+// newFakeSession returns a session whose commands are answered by the fake
+// driver with the given routes.
+func newFakeSession(t *testing.T, routes map[string]fakeResponse) *Session {
+	t.Helper()
+
+	return &Session{
+		id:              "fake",
+		locatorStrategy: selectors.CSS,
+		api: &apiClient{
+			baseURL:  newFakeDriver(t, routes),
+			ctx:      context.Background(),
+			commands: &commandLog{},
+		},
+	}
+}
+
+// setFakeClient sets the client whose sessions are created by the fake driver
+// with the given routes and restores the previous client when the test ends.
+// The driver is ready, and sessions with ID "fake" can be created and deleted.
+func setFakeClient(t *testing.T, routes map[string]fakeResponse) {
+	t.Helper()
+
+	all := map[string]fakeResponse{
+		"GET /status":          {value: map[string]bool{"ready": true}},
+		"POST /session":        {value: map[string]string{"sessionId": "fake"}},
+		"DELETE /session/fake": {},
+	}
+
+	for route, res := range routes {
+		all[route] = res
+	}
+
+	previous := client
+
+	t.Cleanup(func() {
+		client = previous
+	})
+
+	client = &clientParams{
+		api: &apiClient{
+			baseURL:  newFakeDriver(t, all),
+			ctx:      context.Background(),
+			commands: &commandLog{},
+		},
+		driver: &Driver{},
+		ss:     &sessionStore{sessions: make(map[*Session]bool)},
+	}
+}
+
+// requests returns how many times the session has sent the command, e.g.,
+// "POST /session/fake/element/el/click".
+func requests(s *Session, command string) int {
+	n := 0
+
+	for _, c := range s.api.commands.entries() {
+		if c.method+" "+c.route == command {
+			n++
+		}
+	}
+
+	return n
+}
+
+// fakeElement is the response of the fake driver to a found element.
+var fakeElement = fakeResponse{
+	value: map[string]string{"element-6066-11e4-a52e-4f735466cecf": "el"},
+}
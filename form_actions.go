@@ -0,0 +1,324 @@
+package selenium
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/aleksslitvinovs/go-selenium/selectors"
+	"github.com/pkg/errors"
+)
+
+// Struct tags used by FillForm and ReadForm to locate form controls.
+const (
+	selectorTag = "sel"
+	nameTag     = "name"
+)
+
+// formField describes a struct field that is mapped to a form control.
+type formField struct {
+	name      string
+	value     reflect.Value
+	locator   E
+	omitEmpty bool
+}
+
+// FillForm fills the controls of the given form using the values of struct v.
+// The form can be either a selector (uses session's default locator), *E, E or
+// *Element. Controls are located within the form using field's struct tags:
+// `sel:"#email"` (CSS selector) or `name:"email"` (control's name attribute).
+// Untagged fields and fields tagged with "-" are skipped. Tag option
+// omitempty, e.g., `name:"email,omitempty"`, skips fields with zero value.
+//
+// String and numeric fields are typed into inputs, select the option with the
+// same text in <select>, check the radio button with the same value or are
+// used as a file path for file inputs. Bool fields check or uncheck
+// checkboxes. []string fields select options in multi-select elements.
+func (s *Session) FillForm(form interface{}, v interface{}) *Session {
+	fields, err := formFields(v, false)
+	if err != nil {
+		s.handleError(nil, errors.Wrap(err, "failed to fill form"))
+
+		return s
+	}
+
+	f := s.formElement(form)
+
+	for _, field := range fields {
+		if field.omitEmpty && field.value.IsZero() {
+			continue
+		}
+
+		err := fillField(f.newChildElement(field.locator), field)
+		if err != nil {
+			s.handleError(
+				nil,
+				errors.Wrapf(err, "failed to fill %q field", field.name),
+			)
+		}
+	}
+
+	return s
+}
+
+// ReadForm populates struct v, which must be a pointer, with the current
+// values of the form's controls. Controls are located the same way as in
+// FillForm.
+func (s *Session) ReadForm(form interface{}, v interface{}) *Session {
+	fields, err := formFields(v, true)
+	if err != nil {
+		s.handleError(nil, errors.Wrap(err, "failed to read form"))
+
+		return s
+	}
+
+	f := s.formElement(form)
+
+	for _, field := range fields {
+		err := readField(f.newChildElement(field.locator), field)
+		if err != nil {
+			s.handleError(
+				nil,
+				errors.Wrapf(err, "failed to read %q field", field.name),
+			)
+		}
+	}
+
+	return s
+}
+
+func (s *Session) formElement(form interface{}) *Element {
+	if e, ok := form.(*Element); ok {
+		return e
+	}
+
+	return s.NewElement(form)
+}
+
+func formFields(v interface{}, mustBePointer bool) ([]formField, error) {
+	rv := reflect.ValueOf(v)
+
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	} else if mustBePointer {
+		return nil, errors.Errorf("expected pointer to struct, got %T", v)
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.Errorf("expected struct, got %T", v)
+	}
+
+	fields := make([]formField, 0, rv.NumField())
+
+	for i := 0; i < rv.NumField(); i++ {
+		sf := rv.Type().Field(i)
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		locator, omitEmpty, ok := parseFormTag(sf.Tag)
+		if !ok {
+			continue
+		}
+
+		fields = append(fields, formField{
+			name:      sf.Name,
+			value:     rv.Field(i),
+			locator:   locator,
+			omitEmpty: omitEmpty,
+		})
+	}
+
+	return fields, nil
+}
+
+func parseFormTag(tag reflect.StructTag) (E, bool, bool) {
+	value, isSelector := tag.Lookup(selectorTag)
+	if !isSelector {
+		var ok bool
+
+		value, ok = tag.Lookup(nameTag)
+		if !ok {
+			return E{}, false, false
+		}
+	}
+
+	parts := strings.Split(value, ",")
+	if parts[0] == "" || parts[0] == "-" {
+		return E{}, false, false
+	}
+
+	locator := E{Selector: parts[0], SelectorType: selectors.CSS}
+
+	if !isSelector {
+		locator.Selector = fmt.Sprintf("[name=%q]", parts[0])
+	}
+
+	var omitEmpty bool
+
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	return locator, omitEmpty, true
+}
+
+func fillField(e *Element, field formField) error {
+	v := field.value
+
+	switch v.Kind() {
+	case reflect.Bool:
+		e.SetChecked(v.Bool())
+	case reflect.String:
+		return fillText(e, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return fillText(e, fmt.Sprint(v.Interface()))
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return errors.Errorf("unsupported field type %s", v.Type())
+		}
+
+		sel := NewSelect(e)
+		sel.DeselectAll()
+
+		for i := 0; i < v.Len(); i++ {
+			sel.SelectByText(v.Index(i).String())
+		}
+	default:
+		return errors.Errorf("unsupported field type %s", v.Type())
+	}
+
+	return nil
+}
+
+func fillText(e *Element, text string) error {
+	if e.GetTagName() == "select" {
+		NewSelect(e).SelectByText(text)
+
+		return nil
+	}
+
+	switch e.GetProperty("type") {
+	case "radio":
+		return checkRadio(e, text)
+	case "file":
+		e.UploadFile(text)
+	default:
+		e.Clear()
+
+		if text != "" {
+			e.SendKeys(text)
+		}
+	}
+
+	return nil
+}
+
+// checkRadio checks the radio button that has the given value within the
+// radio group located by e.
+func checkRadio(e *Element, value string) error {
+	radios := e.parent.newChildElements(e.E)
+
+	for _, r := range radios.Elements() {
+		if r.GetProperty("value") == value {
+			r.Check()
+
+			return nil
+		}
+	}
+
+	return errors.Errorf("radio button with value %q not found", value)
+}
+
+func readField(e *Element, field formField) error {
+	v := field.value
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(e.IsSelected())
+	case reflect.String:
+		v.SetString(readText(e))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64:
+		n, err := strconv.ParseInt(readText(e), 10, v.Type().Bits())
+		if err != nil {
+			return errors.Wrap(err, "failed to parse integer")
+		}
+
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		n, err := strconv.ParseUint(readText(e), 10, v.Type().Bits())
+		if err != nil {
+			return errors.Wrap(err, "failed to parse unsigned integer")
+		}
+
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(readText(e), v.Type().Bits())
+		if err != nil {
+			return errors.Wrap(err, "failed to parse float")
+		}
+
+		v.SetFloat(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return errors.Errorf("unsupported field type %s", v.Type())
+		}
+
+		selected := NewSelect(e).SelectedOptions()
+		texts := reflect.MakeSlice(v.Type(), 0, len(selected))
+
+		for _, o := range selected {
+			texts = reflect.Append(
+				texts, reflect.ValueOf(o.GetText()).Convert(v.Type().Elem()),
+			)
+		}
+
+		v.Set(texts)
+	default:
+		return errors.Errorf("unsupported field type %s", v.Type())
+	}
+
+	return nil
+}
+
+func readText(e *Element) string {
+	if e.GetTagName() == "select" {
+		o := NewSelect(e).FirstSelectedOption()
+		if o == nil {
+			return ""
+		}
+
+		return o.GetText()
+	}
+
+	if e.GetProperty("type") == "radio" {
+		radios := e.parent.newChildElements(e.E)
+
+		for _, r := range radios.Elements() {
+			if r.IsSelected() {
+				v, _ := r.GetProperty("value").(string)
+
+				return v
+			}
+		}
+
+		return ""
+	}
+
+	switch v := e.GetProperty("value").(type) {
+	case string:
+		return v
+	case nil:
+		return e.GetText()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,338 @@
+package selenium
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aleksslitvinovs/go-selenium/selectors"
+)
+
+func TestParseFormTag(t *testing.T) {
+	tests := []struct {
+		name          string
+		tag           reflect.StructTag
+		want          E
+		wantOmitEmpty bool
+		wantOK        bool
+	}{
+		{
+			name:   "selector",
+			tag:    `sel:"#email"`,
+			want:   E{Selector: "#email", SelectorType: selectors.CSS},
+			wantOK: true,
+		},
+		{
+			name:   "name",
+			tag:    `name:"email"`,
+			want:   E{Selector: `[name="email"]`, SelectorType: selectors.CSS},
+			wantOK: true,
+		},
+		{
+			name:   "selector takes precedence over name",
+			tag:    `name:"email" sel:"#email"`,
+			want:   E{Selector: "#email", SelectorType: selectors.CSS},
+			wantOK: true,
+		},
+		{
+			name: "omitempty",
+			tag:  `name:"email,omitempty"`,
+			want: E{
+				Selector:     `[name="email"]`,
+				SelectorType: selectors.CSS,
+			},
+			wantOmitEmpty: true,
+			wantOK:        true,
+		},
+		{name: "skipped", tag: `sel:"-"`},
+		{name: "empty", tag: `name:",omitempty"`},
+		{name: "untagged", tag: `json:"email"`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, omitEmpty, ok := parseFormTag(tt.tag)
+
+			if got != tt.want || omitEmpty != tt.wantOmitEmpty ||
+				ok != tt.wantOK {
+				t.Errorf(
+					"parseFormTag() = %+v, %t, %t, want %+v, %t, %t",
+					got, omitEmpty, ok, tt.want, tt.wantOmitEmpty, tt.wantOK,
+				)
+			}
+		})
+	}
+}
+
+func TestFormFields(t *testing.T) {
+	type form struct {
+		Email    string `name:"email"`
+		Password string `sel:"#password"`
+		Remember bool
+		token    string `name:"token"` //nolint:unused
+	}
+
+	fields, err := formFields(form{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fields) != 2 || fields[0].name != "Email" ||
+		fields[1].name != "Password" {
+		t.Errorf("unexpected fields %+v", fields)
+	}
+
+	if _, err := formFields(form{}, true); err == nil {
+		t.Error("form is read into a struct that is not a pointer")
+	}
+
+	if _, err := formFields("email", false); err == nil {
+		t.Error("form is filled from a value that is not a struct")
+	}
+}
+
+// formRoutes describe the form "f" with the following controls:
+//   - "text", "number" and "bad" inputs with values "John", "42" and "x";
+//   - unchecked "checkbox";
+//   - "radio" group of "r1" (value "a") and checked "r2" (value "b");
+//   - "select" with options "o1" ("One") and selected "o2" ("Two");
+//   - "multi" select with selected option "m1" ("Red") and "m2" ("Blue").
+func formRoutes() map[string]fakeResponse {
+	routes := map[string]fakeResponse{}
+
+	element := func(id, tag, inputType string, value interface{}) {
+		prefix := "/session/fake/element/" + id
+
+		routes["GET "+prefix+"/name"] = fakeResponse{value: tag}
+		routes["GET "+prefix+"/property/type"] = fakeResponse{value: inputType}
+		routes["GET "+prefix+"/property/value"] = fakeResponse{value: value}
+		routes["POST "+prefix+"/clear"] = fakeResponse{}
+		routes["POST "+prefix+"/value"] = fakeResponse{}
+		routes["POST "+prefix+"/click"] = fakeResponse{}
+	}
+
+	option := func(id, text string, selected bool) {
+		prefix := "/session/fake/element/" + id
+
+		routes["GET "+prefix+"/text"] = fakeResponse{value: text}
+		routes["GET "+prefix+"/selected"] = fakeResponse{value: selected}
+		routes["POST "+prefix+"/click"] = fakeResponse{}
+	}
+
+	children := func(parent string, ids ...string) {
+		refs := make([]map[string]string, 0, len(ids))
+
+		for _, id := range ids {
+			refs = append(refs, map[string]string{webElementID: id})
+		}
+
+		routes["POST /session/fake/element/"+parent+"/elements"] = fakeResponse{
+			value: refs,
+		}
+	}
+
+	element("text", "INPUT", "text", "John")
+	element("number", "input", "number", "42")
+	element("bad", "input", "text", "x")
+	element("checkbox", "input", "checkbox", "on")
+	option("checkbox", "", false)
+
+	element("radio", "input", "radio", "a")
+	children("f", "r1", "r2")
+	element("r1", "input", "radio", "a")
+	option("r1", "", false)
+	element("r2", "input", "radio", "b")
+	option("r2", "", true)
+
+	element("select", "select", "", nil)
+	routes["GET /session/fake/element/select/property/multiple"] = fakeResponse{
+		value: false,
+	}
+	children("select", "o1", "o2")
+	option("o1", "One", false)
+	option("o2", "Two", true)
+
+	element("multi", "select", "", nil)
+	routes["GET /session/fake/element/multi/property/multiple"] = fakeResponse{
+		value: true,
+	}
+	children("multi", "m1", "m2")
+	option("m1", "Red", true)
+	option("m2", "Blue", false)
+
+	return routes
+}
+
+// formControl returns the control of the form "f" with the given ID.
+func formControl(s *Session, id string) *Element {
+	form := s.NewElement("#f")
+	form.id = "f"
+
+	e := form.newChildElement("#" + id)
+	e.id = id
+
+	return e
+}
+
+func TestFillField(t *testing.T) {
+	tests := []struct {
+		name    string
+		control string
+		value   interface{}
+		clicks  []string
+		typed   bool
+		wantErr bool
+	}{
+		{name: "text", control: "text", value: "Jane", typed: true},
+		{name: "number", control: "number", value: 7, typed: true},
+		{
+			name:    "check",
+			control: "checkbox",
+			value:   true,
+			clicks:  []string{"checkbox"},
+		},
+		{name: "radio", control: "radio", value: "a", clicks: []string{"r1"}},
+		{name: "missing radio", control: "radio", value: "c", wantErr: true},
+		{
+			name:    "select",
+			control: "select",
+			value:   "One",
+			clicks:  []string{"o1"},
+		},
+		{
+			name:    "multi select",
+			control: "multi",
+			value:   []string{"Blue"},
+			clicks:  []string{"m1", "m2"},
+		},
+		{
+			name:    "unsupported",
+			control: "text",
+			value:   map[string]string{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t).SoftAsserts = true
+
+			s := newFakeSession(t, formRoutes())
+
+			err := fillField(formControl(s, tt.control), formField{
+				value: reflect.ValueOf(tt.value),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fillField() = %v, wantErr %t", err, tt.wantErr)
+			}
+
+			var clicks []string
+
+			for _, id := range []string{
+				"checkbox", "r1", "r2", "o1", "o2", "m1", "m2",
+			} {
+				if requests(s, "POST /session/fake/element/"+id+"/click") > 0 {
+					clicks = append(clicks, id)
+				}
+			}
+
+			if !equalStrings(clicks, tt.clicks) {
+				t.Errorf("expected %v to be clicked, got %v", tt.clicks, clicks)
+			}
+
+			route := "POST /session/fake/element/" + tt.control + "/value"
+			if typed := requests(s, route) > 0; typed != tt.typed {
+				t.Errorf("text is typed: %t, want %t", typed, tt.typed)
+			}
+
+			if failures := s.Failures(); len(failures) != 0 {
+				t.Errorf("unexpected failures %v", failures)
+			}
+		})
+	}
+}
+
+func TestReadField(t *testing.T) {
+	var (
+		text    string
+		number  int
+		float   float64
+		checked = true
+		radio   string
+		option  string
+		options []string
+		bad     int
+	)
+
+	tests := []struct {
+		control string
+		value   interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{control: "text", value: &text, want: "John"},
+		{control: "number", value: &number, want: 42},
+		{control: "number", value: &float, want: 42.0},
+		{control: "checkbox", value: &checked, want: false},
+		{control: "radio", value: &radio, want: "b"},
+		{control: "select", value: &option, want: "Two"},
+		{control: "multi", value: &options, want: []string{"Red"}},
+		{control: "bad", value: &bad, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.control, func(t *testing.T) {
+			setTestConfig(t).SoftAsserts = true
+
+			s := newFakeSession(t, formRoutes())
+
+			v := reflect.ValueOf(tt.value).Elem()
+
+			err := readField(formControl(s, tt.control), formField{value: v})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readField() = %v, wantErr %t", err, tt.wantErr)
+			}
+
+			if got := v.Interface(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("read %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillFormSkipsEmptyFields(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	routes := formRoutes()
+	routes["POST /session/fake/element"] = fakeResponse{
+		value: map[string]string{webElementID: "f"},
+	}
+	routes["POST /session/fake/element/f/element"] = fakeResponse{
+		value: map[string]string{webElementID: "text"},
+	}
+
+	s := newFakeSession(t, routes)
+
+	s.FillForm("#f", struct {
+		Name     string `name:"name"`
+		Nickname string `name:"nickname,omitempty"`
+		Skipped  string `sel:"-"`
+	}{Name: "Jane", Skipped: "x"})
+
+	if n := requests(s, "POST /session/fake/element/f/element"); n != 1 {
+		t.Errorf("expected 1 control to be located, got %d", n)
+	}
+
+	if n := requests(s, "POST /session/fake/element/text/value"); n != 1 {
+		t.Errorf("expected 1 control to be filled, got %d", n)
+	}
+
+	if failures := s.Failures(); len(failures) != 0 {
+		t.Errorf("unexpected failures %v", failures)
+	}
+}
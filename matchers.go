@@ -0,0 +1,185 @@
+package selenium
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Matcher compares the actual value with the expected one. Custom matchers
+// can be used in assertions via Valuer.Satisfy.
+type Matcher interface {
+	// Match reports whether the actual value satisfies the matcher. An error
+	// is returned if the value cannot be compared, e.g., it is not a number.
+	Match(actual string) (bool, error)
+	// Past describes the expectation in failure messages, e.g.,
+	// `been equal to "foo"`.
+	Past() string
+	// Present describes the expectation in success messages, e.g.,
+	// `equal "foo"`.
+	Present() string
+}
+
+// comparer is a Matcher that is used by the built-in assertions.
+type comparer struct {
+	past    string
+	present string
+	match   func(actual string) (bool, error)
+}
+
+func (cmp *comparer) Match(actual string) (bool, error) {
+	return cmp.match(actual)
+}
+
+func (cmp *comparer) Past() string {
+	return cmp.past
+}
+
+func (cmp *comparer) Present() string {
+	return cmp.present
+}
+
+func newComparer(
+	past, present string, expected interface{}, match func(string) bool,
+) *comparer {
+	return &comparer{
+		past:    fmt.Sprintf("%s %q", past, expected),
+		present: fmt.Sprintf("%s %q", present, expected),
+		match: func(actual string) (bool, error) {
+			return match(actual), nil
+		},
+	}
+}
+
+func equalTo(expected string) Matcher {
+	return newComparer(
+		"been equal to", "equal", expected,
+		func(actual string) bool { return actual == expected },
+	)
+}
+
+func startWith(expected string) Matcher {
+	return newComparer(
+		"started with", "start with", expected,
+		func(actual string) bool { return strings.HasPrefix(actual, expected) },
+	)
+}
+
+func endWith(expected string) Matcher {
+	return newComparer(
+		"ended with", "end with", expected,
+		func(actual string) bool { return strings.HasSuffix(actual, expected) },
+	)
+}
+
+func contain(expected string) Matcher {
+	return newComparer(
+		"contained", "contain", expected,
+		func(actual string) bool { return strings.Contains(actual, expected) },
+	)
+}
+
+func matchRegexp(re *regexp.Regexp) Matcher {
+	return newComparer(
+		"matched", "match", re.String(),
+		re.MatchString,
+	)
+}
+
+func equalToIgnoringCase(expected string) Matcher {
+	return newComparer(
+		"been equal (ignoring case) to", "equal (ignoring case)", expected,
+		func(actual string) bool { return strings.EqualFold(actual, expected) },
+	)
+}
+
+func containIgnoringCase(expected string) Matcher {
+	return newComparer(
+		"contained (ignoring case)", "contain (ignoring case)", expected,
+		func(actual string) bool {
+			return strings.Contains(
+				strings.ToLower(actual), strings.ToLower(expected),
+			)
+		},
+	)
+}
+
+func equalToIgnoringWhitespace(expected string) Matcher {
+	return newComparer(
+		"been equal (ignoring whitespace) to",
+		"equal (ignoring whitespace)",
+		expected,
+		func(actual string) bool {
+			return normalizeWhitespace(actual) == normalizeWhitespace(expected)
+		},
+	)
+}
+
+func oneOf(expected []string) Matcher {
+	return newComparer(
+		"been one of", "equal one of", expected,
+		func(actual string) bool {
+			for _, e := range expected {
+				if actual == e {
+					return true
+				}
+			}
+
+			return false
+		},
+	)
+}
+
+func greaterThan(expected float64) Matcher {
+	return &comparer{
+		past:    fmt.Sprintf("been greater than %v", expected),
+		present: fmt.Sprintf("exceed %v", expected),
+		match: func(actual string) (bool, error) {
+			n, err := parseNumber(actual)
+
+			return err == nil && n > expected, err
+		},
+	}
+}
+
+func lessThan(expected float64) Matcher {
+	return &comparer{
+		past:    fmt.Sprintf("been less than %v", expected),
+		present: fmt.Sprintf("fall below %v", expected),
+		match: func(actual string) (bool, error) {
+			n, err := parseNumber(actual)
+
+			return err == nil && n < expected, err
+		},
+	}
+}
+
+func atLeast(expected float64) Matcher {
+	return &comparer{
+		past:    fmt.Sprintf("been at least %v", expected),
+		present: fmt.Sprintf("reach %v", expected),
+		match: func(actual string) (bool, error) {
+			n, err := parseNumber(actual)
+
+			return err == nil && n >= expected, err
+		},
+	}
+}
+
+// normalizeWhitespace trims the value and replaces all whitespace sequences
+// with a single space.
+func normalizeWhitespace(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func parseNumber(value string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, errors.Errorf("value %q is not a number", value)
+	}
+
+	return n, nil
+}
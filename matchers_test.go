@@ -0,0 +1,128 @@
+package selenium
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestMatchers(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher Matcher
+		actual  string
+		want    bool
+		wantErr bool
+	}{
+		{name: "equal", matcher: equalTo("foo"), actual: "foo", want: true},
+		{name: "not equal", matcher: equalTo("foo"), actual: "Foo"},
+		{
+			name:    "start with",
+			matcher: startWith("foo"),
+			actual:  "foobar",
+			want:    true,
+		},
+		{
+			name:    "end with",
+			matcher: endWith("bar"),
+			actual:  "foobar",
+			want:    true,
+		},
+		{name: "contain", matcher: contain("ob"), actual: "foobar", want: true},
+		{name: "not contain", matcher: contain("baz"), actual: "foobar"},
+		{
+			name:    "match",
+			matcher: matchRegexp(regexp.MustCompile(`^\d+ items$`)),
+			actual:  "12 items",
+			want:    true,
+		},
+		{
+			name:    "equal ignoring case",
+			matcher: equalToIgnoringCase("Foo"),
+			actual:  "fOO",
+			want:    true,
+		},
+		{
+			name:    "contain ignoring case",
+			matcher: containIgnoringCase("BAR"),
+			actual:  "foobar",
+			want:    true,
+		},
+		{
+			name:    "equal ignoring whitespace",
+			matcher: equalToIgnoringWhitespace("foo bar"),
+			actual:  "  foo\n\t bar ",
+			want:    true,
+		},
+		{
+			name:    "one of",
+			matcher: oneOf([]string{"a", "b"}),
+			actual:  "b",
+			want:    true,
+		},
+		{name: "not one of", matcher: oneOf([]string{"a", "b"}), actual: "c"},
+		{
+			name:    "greater than",
+			matcher: greaterThan(10),
+			actual:  " 10.5 ",
+			want:    true,
+		},
+		{name: "not greater than", matcher: greaterThan(10), actual: "10"},
+		{name: "less than", matcher: lessThan(10), actual: "-1", want: true},
+		{name: "at least", matcher: atLeast(10), actual: "10", want: true},
+		{
+			name:    "not a number",
+			matcher: atLeast(10),
+			actual:  "ten",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.matcher.Match(tt.actual)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Match(%q) = %t, want %t", tt.actual, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcherDescriptions(t *testing.T) {
+	tests := []struct {
+		matcher Matcher
+		past    string
+		present string
+	}{
+		{
+			matcher: equalTo("foo"),
+			past:    `been equal to "foo"`,
+			present: `equal "foo"`,
+		},
+		{
+			matcher: oneOf([]string{"a", "b"}),
+			past:    `been one of ["a" "b"]`,
+			present: `equal one of ["a" "b"]`,
+		},
+		{
+			matcher: greaterThan(1.5),
+			past:    "been greater than 1.5",
+			present: "exceed 1.5",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.matcher.Past(); got != tt.past {
+			t.Errorf("Past() = %q, want %q", got, tt.past)
+		}
+
+		if got := tt.matcher.Present(); got != tt.present {
+			t.Errorf("Present() = %q, want %q", got, tt.present)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package selenium
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Supported page orientations for PrintOptions.
+const (
+	PortraitOrientation  = "portrait"
+	LandscapeOrientation = "landscape"
+)
+
+// PrintOptions describes how the current page should be printed. Zero values
+// are omitted, i.e., browser driver's defaults are used. Page size and margins
+// are described in centimeters.
+//
+//nolint:tagliatelle
+type PrintOptions struct {
+	Orientation string        `json:"orientation,omitempty"`
+	Scale       float64       `json:"scale,omitempty"`
+	Background  bool          `json:"background,omitempty"`
+	Page        *PrintPage    `json:"page,omitempty"`
+	Margin      *PrintMargin  `json:"margin,omitempty"`
+	ShrinkToFit *bool         `json:"shrinkToFit,omitempty"`
+	PageRanges  []interface{} `json:"pageRanges,omitempty"`
+}
+
+// PrintPage describes page size in centimeters.
+type PrintPage struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// PrintMargin describes page margins in centimeters. Nil margins are omitted,
+// i.e., browser driver's default (1 cm) is used.
+type PrintMargin struct {
+	Top    *float64 `json:"top,omitempty"`
+	Bottom *float64 `json:"bottom,omitempty"`
+	Left   *float64 `json:"left,omitempty"`
+	Right  *float64 `json:"right,omitempty"`
+}
+
+// Commonly used page sizes.
+var (
+	A4Page     = &PrintPage{Width: 21, Height: 29.7}
+	LetterPage = &PrintPage{Width: 21.59, Height: 27.94}
+)
+
+// PrintPDF prints the current page to PDF and returns its content. If opts is
+// nil, browser driver's defaults are used. Page ranges can be either page
+// numbers (int) or ranges written as a string, e.g., "1-3".
+// Reference: https://www.w3.org/TR/webdriver/#print-page
+func (s *Session) PrintPDF(opts *PrintOptions) []byte {
+	if opts == nil {
+		opts = &PrintOptions{}
+	}
+
+	err := opts.validate()
+	if err != nil {
+		s.handleError(nil, err)
+
+		return nil
+	}
+
+	res, err := s.api.executeRequest(
+		http.MethodPost, fmt.Sprintf("/session/%s/print", s.id), opts,
+	)
+	if err != nil {
+		s.handleError(res, err)
+
+		return nil
+	}
+
+	v, ok := res.Value.(string)
+	if !ok {
+		s.handleError(nil, errors.New("failed to print page"))
+
+		return nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(v)
+	if err != nil {
+		s.handleError(nil, errors.Wrap(err, "failed to decode base64"))
+
+		return nil
+	}
+
+	return data
+}
+
+// SavePDF prints the current page to PDF the same way as PrintPDF. PDF file is
+// created in screenshot_path directory based on the config. The file must have
+// .pdf extension.
+func (s *Session) SavePDF(name string, opts *PrintOptions) *Session {
+	if !strings.HasSuffix(name, ".pdf") {
+		s.handleError(nil, errors.New("PDF name must end with .pdf"))
+
+		return s
+	}
+
+	data := s.PrintPDF(opts)
+	if data == nil {
+		return s
+	}
+
+	err := os.WriteFile(path.Join(config.ScreenshotDir, name), data, 0644)
+	if err != nil {
+		s.handleError(nil, errors.Wrap(err, "failed to create PDF file"))
+	}
+
+	return s
+}
+
+func (opts *PrintOptions) validate() error {
+	switch opts.Orientation {
+	case "", PortraitOrientation, LandscapeOrientation:
+	default:
+		return errors.Errorf("unsupported orientation %q", opts.Orientation)
+	}
+
+	if opts.Scale != 0 && (opts.Scale < 0.1 || opts.Scale > 2) {
+		return errors.Errorf(
+			"scale must be between 0.1 and 2, got %v", opts.Scale,
+		)
+	}
+
+	if opts.Page != nil && (opts.Page.Width <= 0 || opts.Page.Height <= 0) {
+		return errors.New("page width and height must be greater than 0")
+	}
+
+	if m := opts.Margin; m != nil {
+		for _, margin := range []*float64{m.Top, m.Bottom, m.Left, m.Right} {
+			if margin != nil && *margin < 0 {
+				return errors.New("page margins cannot be negative")
+			}
+		}
+	}
+
+	for _, r := range opts.PageRanges {
+		switch r.(type) {
+		case int, string:
+		default:
+			return errors.Errorf("unsupported page range type %T", r)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package selenium
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+)
+
+func TestPrintOptionsValidate(t *testing.T) {
+	negative := -1.0
+	zero := 0.0
+
+	tests := []struct {
+		name    string
+		opts    *PrintOptions
+		wantErr bool
+	}{
+		{name: "defaults", opts: &PrintOptions{}},
+		{
+			name: "all options",
+			opts: &PrintOptions{
+				Orientation: LandscapeOrientation,
+				Scale:       0.5,
+				Page:        A4Page,
+				Margin:      &PrintMargin{Top: &zero},
+				PageRanges:  []interface{}{1, "3-5"},
+			},
+		},
+		{
+			name:    "unsupported orientation",
+			opts:    &PrintOptions{Orientation: "sideways"},
+			wantErr: true,
+		},
+		{
+			name:    "scale too small",
+			opts:    &PrintOptions{Scale: 0.05},
+			wantErr: true,
+		},
+		{
+			name:    "scale too large",
+			opts:    &PrintOptions{Scale: 2.5},
+			wantErr: true,
+		},
+		{
+			name:    "empty page",
+			opts:    &PrintOptions{Page: &PrintPage{Width: 21}},
+			wantErr: true,
+		},
+		{
+			name:    "negative margin",
+			opts:    &PrintOptions{Margin: &PrintMargin{Right: &negative}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported page range",
+			opts:    &PrintOptions{PageRanges: []interface{}{1.5}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrintMarginOmitsUnsetMargins(t *testing.T) {
+	top := 2.0
+
+	data, err := json.Marshal(&PrintOptions{Margin: &PrintMargin{Top: &top}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `{"margin":{"top":2}}` {
+		t.Errorf("unexpected payload %s", data)
+	}
+}
+
+func TestPrintPDF(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/print": {
+			value: base64.StdEncoding.EncodeToString([]byte("%PDF")),
+		},
+	})
+
+	if data := s.PrintPDF(nil); string(data) != "%PDF" {
+		t.Errorf("unexpected PDF %q", data)
+	}
+
+	if data := s.PrintPDF(&PrintOptions{Scale: 3}); data != nil {
+		t.Errorf("invalid options are sent: %q", data)
+	}
+
+	if failures := s.Failures(); len(failures) != 1 {
+		t.Errorf("expected 1 failure, got %v", failures)
+	}
+}
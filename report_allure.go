@@ -0,0 +1,444 @@
+package selenium
+
+import (
+	"crypto/md5" //nolint:gosec
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Allure results format, see https://allurereport.org/docs/how-it-works/.
+// Each attempt is written as a separate result with the same historyId, so
+// that Allure shows previous attempts as retries.
+type allureResult struct {
+	UUID          string              `json:"uuid"`
+	HistoryID     string              `json:"historyId"`
+	TestCaseID    string              `json:"testCaseId"`
+	FullName      string              `json:"fullName"`
+	Name          string              `json:"name"`
+	Status        string              `json:"status"`
+	StatusDetails *allureStatusDetail `json:"statusDetails,omitempty"`
+	Stage         string              `json:"stage"`
+	Steps         []allureStep        `json:"steps"`
+	Attachments   []allureAttachment  `json:"attachments"`
+	Parameters    []allureParameter   `json:"parameters"`
+	Labels        []allureLabel       `json:"labels"`
+	Links         []allureLink        `json:"links"`
+	Start         int64               `json:"start"`
+	Stop          int64               `json:"stop"`
+}
+
+type allureContainer struct {
+	UUID     string       `json:"uuid"`
+	Name     string       `json:"name"`
+	Children []string     `json:"children"`
+	Befores  []allureStep `json:"befores"`
+	Afters   []allureStep `json:"afters"`
+	Start    int64        `json:"start"`
+	Stop     int64        `json:"stop"`
+}
+
+type allureStatusDetail struct {
+	Flaky   bool   `json:"flaky,omitempty"`
+	Message string `json:"message,omitempty"`
+	Trace   string `json:"trace,omitempty"`
+}
+
+type allureStep struct {
+	Name          string              `json:"name"`
+	Status        string              `json:"status"`
+	StatusDetails *allureStatusDetail `json:"statusDetails,omitempty"`
+	Stage         string              `json:"stage"`
+	Steps         []allureStep        `json:"steps"`
+	Attachments   []allureAttachment  `json:"attachments"`
+	Start         int64               `json:"start"`
+	Stop          int64               `json:"stop"`
+}
+
+type allureAttachment struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Type   string `json:"type"`
+}
+
+type allureParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type allureLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type allureLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Type string `json:"type"`
+}
+
+const allureStageFinished = "finished"
+
+// allureReporter writes Allure results to the output directory when the run
+// ends.
+type allureReporter struct {
+	output string
+}
+
+func (rep *allureReporter) HandleEvent(e *Event) error {
+	return writeReport(e, allureReport, rep.output, writeAllureResults)
+}
+
+// allureWriter writes results of a single run to the results directory.
+type allureWriter struct {
+	dir string
+}
+
+func writeAllureResults(output string, tests []*TestResult) error {
+	err := os.MkdirAll(output, 0755)
+	if err != nil {
+		return errors.Wrap(err, "failed to create results directory")
+	}
+
+	w := &allureWriter{dir: output}
+
+	for _, t := range tests {
+		err := w.writeTest(t)
+		if err != nil {
+			return errors.Wrapf(err, "failed to write results of %q", t.Name)
+		}
+	}
+
+	return nil
+}
+
+func (w *allureWriter) writeTest(t *TestResult) error {
+	if t.Status == StatusSkipped {
+		now := time.Now()
+
+		res := w.newResult(t, now, now)
+		res.Status = StatusSkipped
+		res.StatusDetails = &allureStatusDetail{Message: t.SkipReason}
+
+		return w.writeJSON(res.UUID+"-result.json", res)
+	}
+
+	for i, a := range t.Attempts {
+		stop := a.Start.Add(a.Duration)
+
+		res := w.newResult(t, a.Start, stop)
+		res.Status = allureStatus(a.Failed)
+		res.Steps = w.newSteps(a.Steps)
+
+		if a.Failed {
+			res.StatusDetails = newAllureStatusDetail(a.Failures)
+		}
+
+		if t.Status == StatusFlaky && i == len(t.Attempts)-1 {
+			res.StatusDetails = &allureStatusDetail{Flaky: true}
+		}
+
+		res.Attachments = w.attachScreenshots(a.Failures)
+		res.Attachments = append(
+			res.Attachments, w.attachArtifacts(a.Artifacts, a.Failures)...,
+		)
+
+		if len(a.Log) > 0 {
+			att, err := w.attach(
+				"log", "text/plain", ".txt",
+				[]byte(strings.Join(a.Log, "\n")),
+			)
+			if err != nil {
+				return err
+			}
+
+			res.Attachments = append(res.Attachments, att)
+		}
+
+		err := w.writeJSON(res.UUID+"-result.json", res)
+		if err != nil {
+			return err
+		}
+
+		if len(a.Befores) == 0 && len(a.Afters) == 0 {
+			continue
+		}
+
+		container := allureContainer{
+			UUID:     newUUID(),
+			Name:     t.Name,
+			Children: []string{res.UUID},
+			Befores:  w.newSteps(a.Befores),
+			Afters:   w.newSteps(a.Afters),
+			Start:    res.Start,
+			Stop:     res.Stop,
+		}
+
+		err = w.writeJSON(container.UUID+"-container.json", container)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *allureWriter) newResult(
+	t *TestResult, start, stop time.Time,
+) *allureResult {
+	historyID := allureHistoryID(t)
+
+	res := &allureResult{
+		UUID:        newUUID(),
+		HistoryID:   historyID,
+		TestCaseID:  historyID,
+		FullName:    t.Name,
+		Name:        t.Name,
+		Stage:       allureStageFinished,
+		Steps:       []allureStep{},
+		Attachments: []allureAttachment{},
+		Parameters:  []allureParameter{},
+		Links:       []allureLink{},
+		Labels: []allureLabel{
+			{Name: "suite", Value: junitSuiteName},
+			{Name: "framework", Value: "go-selenium"},
+			{Name: "language", Value: "go"},
+		},
+		Start: start.UnixMilli(),
+		Stop:  stop.UnixMilli(),
+	}
+
+	for _, tag := range t.Tags {
+		res.Labels = append(res.Labels, allureLabel{Name: "tag", Value: tag})
+	}
+
+	for _, p := range t.Parameters {
+		res.Parameters = append(res.Parameters, allureParameter(p))
+	}
+
+	for _, l := range t.Links {
+		res.Links = append(res.Links, allureLink{
+			Name: l.Name,
+			URL:  l.URL,
+			Type: "link",
+		})
+	}
+
+	return res
+}
+
+func (w *allureWriter) newSteps(steps []*StepResult) []allureStep {
+	as := make([]allureStep, 0, len(steps))
+
+	for _, step := range steps {
+		s := allureStep{
+			Name:        step.Name,
+			Status:      allureStatus(step.Status == StatusFailed),
+			Stage:       allureStageFinished,
+			Steps:       w.newSteps(step.Steps),
+			Attachments: []allureAttachment{},
+			Start:       step.Start.UnixMilli(),
+			Stop:        step.Start.Add(step.Duration).UnixMilli(),
+		}
+
+		if step.Failure != nil {
+			s.StatusDetails = newAllureStatusDetail(
+				[]*AssertionFailure{step.Failure},
+			)
+		}
+
+		if att, ok := w.attachScreenshot(step.Screenshot); ok {
+			s.Attachments = append(s.Attachments, att)
+		}
+
+		as = append(as, s)
+	}
+
+	return as
+}
+
+// attachScreenshots copies failure screenshots to the results directory.
+func (w *allureWriter) attachScreenshots(
+	failures []*AssertionFailure,
+) []allureAttachment {
+	attachments := []allureAttachment{}
+
+	for _, f := range failures {
+		if att, ok := w.attachScreenshot(f.Screenshot); ok {
+			attachments = append(attachments, att)
+		}
+	}
+
+	return attachments
+}
+
+// attachScreenshot copies the screenshot to the results directory. False is
+// returned if there is no screenshot or it cannot be copied.
+func (w *allureWriter) attachScreenshot(file string) (allureAttachment, bool) {
+	if file == "" {
+		return allureAttachment{}, false
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return allureAttachment{}, false
+	}
+
+	ext := strings.ToLower(filepath.Ext(file))
+
+	mimeType := "image/png"
+	if ext == ".jpg" || ext == ".jpeg" {
+		mimeType = "image/jpeg"
+	}
+
+	att, err := w.attach("screenshot", mimeType, ext, data)
+
+	return att, err == nil
+}
+
+// attachArtifacts copies failure artifacts to the results directory. The
+// screenshot is skipped if it is already attached to the failure.
+func (w *allureWriter) attachArtifacts(
+	a *Artifacts, failures []*AssertionFailure,
+) []allureAttachment {
+	if a == nil {
+		return nil
+	}
+
+	var attachments []allureAttachment
+
+	attached := false
+
+	for _, f := range failures {
+		attached = attached || f.Screenshot == a.Screenshot
+	}
+
+	if !attached {
+		if att, ok := w.attachScreenshot(a.Screenshot); ok {
+			attachments = append(attachments, att)
+		}
+	}
+
+	info := filepath.Join(a.Dir, artifactsInfoFile)
+
+	files := []struct {
+		name, mimeType, file string
+	}{
+		{"page source", "text/html", a.PageSource},
+		{"console logs", "text/plain", a.ConsoleLogs},
+		{"artifacts", "application/json", info},
+	}
+
+	for _, f := range files {
+		if f.file == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(f.file)
+		if err != nil {
+			continue
+		}
+
+		att, err := w.attach(f.name, f.mimeType, filepath.Ext(f.file), data)
+		if err != nil {
+			continue
+		}
+
+		attachments = append(attachments, att)
+	}
+
+	return attachments
+}
+
+func (w *allureWriter) attach(
+	name, mimeType, ext string, data []byte,
+) (allureAttachment, error) {
+	source := newUUID() + "-attachment" + ext
+
+	//nolint:gosec
+	err := os.WriteFile(filepath.Join(w.dir, source), data, 0644)
+	if err != nil {
+		return allureAttachment{}, errors.Wrap(
+			err, "failed to write attachment",
+		)
+	}
+
+	return allureAttachment{Name: name, Source: source, Type: mimeType}, nil
+}
+
+func (w *allureWriter) writeJSON(name string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode %s", name)
+	}
+
+	err = os.WriteFile(filepath.Join(w.dir, name), data, 0644) //nolint:gosec
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s", name)
+	}
+
+	return nil
+}
+
+func newAllureStatusDetail(failures []*AssertionFailure) *allureStatusDetail {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(failures))
+
+	for _, f := range failures {
+		lines = append(lines, f.String())
+	}
+
+	return &allureStatusDetail{
+		Message: failures[0].Message,
+		Trace:   strings.Join(lines, "\n"),
+	}
+}
+
+func allureStatus(failed bool) string {
+	if failed {
+		return StatusFailed
+	}
+
+	return StatusPassed
+}
+
+// allureHistoryID identifies the test across runs. Tests with different
+// parameters are different tests.
+func allureHistoryID(t *TestResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(t.Name)
+
+	for _, p := range t.Parameters {
+		fmt.Fprintf(&sb, "\x00%s=%s", p.Name, p.Value)
+	}
+
+	sum := md5.Sum([]byte(sb.String())) //nolint:gosec
+
+	return hex.EncodeToString(sum[:])
+}
+
+// newUUID returns random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+
+	// Read only fails if the system's random source is unavailable.
+	_, _ = rand.Read(b[:])
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf(
+		"%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:],
+	)
+}
@@ -0,0 +1,57 @@
+package selenium
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestAllureHistoryID(t *testing.T) {
+	checkout := &TestResult{
+		Name:       "checkout",
+		Parameters: []TestParameter{{Name: "browser", Value: "chrome"}},
+	}
+
+	id := allureHistoryID(checkout)
+
+	if got := allureHistoryID(&TestResult{
+		Name:       "checkout",
+		Status:     StatusFailed,
+		Parameters: []TestParameter{{Name: "browser", Value: "chrome"}},
+	}); got != id {
+		t.Errorf("history ID depends on the result: %s != %s", got, id)
+	}
+
+	for _, other := range []*TestResult{
+		{Name: "checkout"},
+		{
+			Name:       "checkout",
+			Parameters: []TestParameter{{Name: "browser", Value: "firefox"}},
+		},
+	} {
+		if allureHistoryID(other) == id {
+			t.Errorf("%+v has the same history ID as %+v", other, checkout)
+		}
+	}
+}
+
+func TestNewUUID(t *testing.T) {
+	v4 := regexp.MustCompile(
+		`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+	)
+
+	seen := make(map[string]bool)
+
+	for i := 0; i < 100; i++ {
+		id := newUUID()
+
+		if !v4.MatchString(id) {
+			t.Fatalf("%q is not a version 4 UUID", id)
+		}
+
+		if seen[id] {
+			t.Fatalf("%q is generated twice", id)
+		}
+
+		seen[id] = true
+	}
+}
@@ -0,0 +1,126 @@
+package selenium
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+	"github.com/fatih/color"
+)
+
+// consoleReporter logs the progress of the run and prints the summary with
+// failures when the run ends.
+type consoleReporter struct{}
+
+func (rep *consoleReporter) HandleEvent(e *Event) error {
+	switch e.Type {
+	case TestStartEvent:
+		logger.Infof("running test: %s", e.Test)
+	case RetryEvent:
+		logger.Warnf("retrying test: %s (%s)", e.Test, e.Message)
+	case RunEndEvent:
+		printSummary(e.Status, e.Results)
+	}
+
+	return nil
+}
+
+func printSummary(status string, results []*TestResult) {
+	var errorCount, skipCount, flakyCount int
+
+	for _, tr := range results {
+		switch tr.Status {
+		case StatusFailed:
+			errorCount++
+		case StatusSkipped:
+			skipCount++
+		case StatusFlaky:
+			flakyCount++
+		}
+	}
+
+	printFailures(results)
+
+	total := len(results) - skipCount
+
+	if skipCount > 0 {
+		logger.Infof("Skipped %d tests", skipCount)
+	}
+
+	if flakyCount > 0 {
+		logger.Warnf("%d tests passed after retrying (flaky)", flakyCount)
+	}
+
+	if status == StatusFailed {
+		logger.Custom(color.RedString(
+			"Failed! Success rate: %d/%d", total-errorCount, total,
+		))
+
+		return
+	}
+
+	logger.Custom(color.GreenString(
+		"Passed! Success rate: %d/%d", total-errorCount, total,
+	))
+}
+
+// printFailures prints failures of all failed and flaky tests grouped by test
+// and attempt.
+func printFailures(results []*TestResult) {
+	var sb strings.Builder
+
+	for _, tr := range results {
+		switch tr.Status {
+		case StatusFlaky:
+			fmt.Fprintf(&sb, "\n%s (flaky):\n", tr.Name)
+		case StatusFailed:
+			fmt.Fprintf(&sb, "\n%s:\n", tr.Name)
+		default:
+			continue
+		}
+
+		for i, a := range tr.Attempts {
+			if !a.Failed {
+				continue
+			}
+
+			if len(tr.Attempts) > 1 {
+				fmt.Fprintf(&sb, "  attempt %d:\n", i+1)
+			}
+
+			writeFailures(&sb, a.Failures)
+
+			if a.Artifacts != nil {
+				fmt.Fprintf(&sb, "  artifacts: %s\n", a.Artifacts.Dir)
+			}
+		}
+	}
+
+	if sb.Len() == 0 {
+		return
+	}
+
+	logger.Custom(color.RedString("Failures:"), sb.String())
+}
+
+func writeFailures(sb *strings.Builder, failures []*AssertionFailure) {
+	for i, f := range failures {
+		fmt.Fprintf(sb, "  %d) %s\n", i+1, f.Message)
+
+		if f.Location() != "" {
+			fmt.Fprintf(sb, "     at %s\n", f.Location())
+		}
+
+		if f.Step != "" {
+			fmt.Fprintf(sb, "     step: %s\n", f.Step)
+		}
+
+		if f.Selector != "" {
+			fmt.Fprintf(sb, "     selector: %q\n", f.Selector)
+		}
+
+		if f.Screenshot != "" {
+			fmt.Fprintf(sb, "     screenshot: %s\n", f.Screenshot)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package selenium
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type htmlReportData struct {
+	Generated string
+	Duration  string
+	Total     int
+	Passed    int
+	Failed    int
+	Flaky     int
+	Skipped   int
+	Tests     []htmlTest
+}
+
+type htmlTest struct {
+	Name       string
+	Status     string
+	Duration   string
+	Tags       []string
+	SkipReason string
+	Attempts   []htmlAttempt
+}
+
+type htmlAttempt struct {
+	Number    int
+	Failed    bool
+	Duration  string
+	SessionID string
+	Log       []string
+	Steps     []htmlStep
+	Failures  []htmlFailure
+	Artifacts *htmlArtifacts
+	Commands  []htmlCommand
+}
+
+type htmlArtifacts struct {
+	Dir           string
+	URL           string
+	Title         string
+	WindowHandles []string
+	Files         []htmlLink
+}
+
+type htmlLink struct {
+	Name string
+	Href string
+}
+
+type htmlStep struct {
+	Name     string
+	Status   string
+	Duration string
+	// Screenshot is only embedded if it is not shown with the failure.
+	Screenshot template.URL
+	Steps      []htmlStep
+}
+
+type htmlFailure struct {
+	Message    string
+	Location   string
+	Step       string
+	Selector   string
+	Screenshot template.URL
+}
+
+type htmlCommand struct {
+	Offset   string
+	Method   string
+	Route    string
+	Status   int
+	Duration string
+	Error    string
+}
+
+// htmlReporter writes HTML report when the run ends.
+type htmlReporter struct {
+	output string
+}
+
+func (rep *htmlReporter) HandleEvent(e *Event) error {
+	return writeReport(e, htmlReport, rep.output, writeHTMLReport)
+}
+
+func writeHTMLReport(output string, tests []*TestResult) error {
+	report := htmlReportData{
+		Generated: time.Now().Format(time.RFC1123),
+		Total:     len(tests),
+	}
+
+	var duration time.Duration
+
+	dir := filepath.Dir(output)
+
+	for _, t := range tests {
+		ht := newHTMLTest(t, dir)
+
+		switch ht.Status {
+		case StatusPassed:
+			report.Passed++
+		case StatusFailed:
+			report.Failed++
+		case StatusFlaky:
+			report.Flaky++
+		case StatusSkipped:
+			report.Skipped++
+		}
+
+		duration += t.Duration()
+
+		report.Tests = append(report.Tests, ht)
+	}
+
+	report.Duration = formatDuration(duration)
+
+	f, err := createReportFile(output)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = htmlReportTemplate.Execute(f, report)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute report template")
+	}
+
+	return nil
+}
+
+// newHTMLTest converts the test's result. Links to the files are relative to
+// the report's directory.
+func newHTMLTest(t *TestResult, dir string) htmlTest {
+	ht := htmlTest{
+		Name:       t.Name,
+		Status:     t.Status,
+		Duration:   formatDuration(t.Duration()),
+		Tags:       t.Tags,
+		SkipReason: t.SkipReason,
+	}
+
+	for i, a := range t.Attempts {
+		ha := htmlAttempt{
+			Number:    i + 1,
+			Failed:    a.Failed,
+			Duration:  formatDuration(a.Duration),
+			SessionID: a.SessionID,
+			Log:       a.Log,
+			Steps:     newHTMLSteps(a.Steps),
+		}
+
+		if a.Artifacts != nil {
+			ha.Artifacts = newHTMLArtifacts(a.Artifacts, dir)
+		}
+
+		for _, c := range a.commands {
+			ha.Commands = append(ha.Commands, newHTMLCommand(c, a.Start))
+		}
+
+		for _, f := range a.Failures {
+			ha.Failures = append(ha.Failures, htmlFailure{
+				Message:    f.Message,
+				Location:   f.Location(),
+				Step:       f.Step,
+				Selector:   f.Selector,
+				Screenshot: embedImage(f.Screenshot),
+			})
+		}
+
+		ht.Attempts = append(ht.Attempts, ha)
+	}
+
+	return ht
+}
+
+func newHTMLSteps(steps []*StepResult) []htmlStep {
+	hs := make([]htmlStep, 0, len(steps))
+
+	for _, step := range steps {
+		s := htmlStep{
+			Name:     step.Name,
+			Status:   step.Status,
+			Duration: formatDuration(step.Duration),
+			Steps:    newHTMLSteps(step.Steps),
+		}
+
+		if step.Failure == nil {
+			s.Screenshot = embedImage(step.Screenshot)
+		}
+
+		hs = append(hs, s)
+	}
+
+	return hs
+}
+
+func newHTMLArtifacts(a *Artifacts, dir string) *htmlArtifacts {
+	ha := &htmlArtifacts{
+		Dir:           a.Dir,
+		URL:           a.URL,
+		Title:         a.Title,
+		WindowHandles: a.WindowHandles,
+	}
+
+	files := a.files()
+	if a.Screenshot != "" {
+		files = append([]string{a.Screenshot}, files...)
+	}
+
+	for _, file := range files {
+		ha.Files = append(ha.Files, htmlLink{
+			Name: filepath.Base(file),
+			Href: relativeLink(dir, file),
+		})
+	}
+
+	return ha
+}
+
+// relativeLink returns the file's path relative to the directory, so that the
+// report can be moved along with the files. Absolute path is returned if the
+// relative one cannot be determined.
+func relativeLink(dir, file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.ToSlash(abs)
+	}
+
+	rel, err := filepath.Rel(absDir, abs)
+	if err != nil {
+		return filepath.ToSlash(abs)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+func newHTMLCommand(c webDriverCommand, startTime time.Time) htmlCommand {
+	hc := htmlCommand{
+		Offset:   formatDuration(c.start.Sub(startTime)),
+		Method:   c.method,
+		Route:    c.route,
+		Status:   c.status,
+		Duration: formatDuration(c.duration),
+	}
+
+	if c.err != nil {
+		hc.Error = c.err.Error()
+	}
+
+	return hc
+}
+
+// embedImage returns the image as data URL, so that the report does not
+// depend on screenshot files. Empty URL is returned if the image cannot be
+// read.
+func embedImage(file string) template.URL {
+	if file == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+
+	mimeType := "image/png"
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".jpg", ".jpeg":
+		mimeType = "image/jpeg"
+	}
+
+	//nolint:gosec
+	return template.URL(fmt.Sprintf(
+		"data:%s;base64,%s",
+		mimeType, base64.StdEncoding.EncodeToString(data),
+	))
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+
+	return d.Round(time.Millisecond).String()
+}
+
+// htmlReportLayout is a self-contained page, i.e., styles and screenshots are
+// embedded.
+//
+//go:embed report_html.tmpl
+var htmlReportLayout string
+
+var htmlReportTemplate = template.Must(
+	template.New("report").Parse(htmlReportLayout),
+)
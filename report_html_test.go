@@ -0,0 +1,46 @@
+package selenium
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestRelativeLink(t *testing.T) {
+	root := t.TempDir()
+
+	tests := []struct {
+		name string
+		dir  string
+		file string
+		want string
+	}{
+		{
+			name: "file in subdirectory",
+			dir:  filepath.Join(root, "reports"),
+			file: filepath.Join(root, "reports", "shots", "a.png"),
+			want: "shots/a.png",
+		},
+		{
+			name: "file in sibling directory",
+			dir:  filepath.Join(root, "reports"),
+			file: filepath.Join(root, "artifacts", "checkout", "page.html"),
+			want: "../artifacts/checkout/page.html",
+		},
+		{
+			name: "relative paths",
+			dir:  "reports",
+			file: "screenshots/a.png",
+			want: "../screenshots/a.png",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relativeLink(tt.dir, tt.file); got != tt.want {
+				t.Errorf("relativeLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package selenium
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// jsonReporter writes events as newline delimited JSON, similar to go test
+// -json, so that they can be processed while the tests are running. Events are
+// written to stdout if the output is "-".
+type jsonReporter struct {
+	output string
+	w      io.Writer
+	enc    *json.Encoder
+}
+
+func (rep *jsonReporter) HandleEvent(e *Event) error {
+	if e.Type == RunStartEvent {
+		err := rep.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	if rep.enc == nil {
+		return nil
+	}
+
+	err := rep.enc.Encode(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to write event")
+	}
+
+	if e.Type == RunEndEvent {
+		return rep.close()
+	}
+
+	return nil
+}
+
+func (rep *jsonReporter) open() error {
+	rep.w = os.Stdout
+
+	if rep.output != "-" {
+		f, err := createReportFile(rep.output)
+		if err != nil {
+			return err
+		}
+
+		rep.w = f
+	}
+
+	rep.enc = json.NewEncoder(rep.w)
+	rep.enc.SetEscapeHTML(false)
+
+	return nil
+}
+
+func (rep *jsonReporter) close() error {
+	rep.enc = nil
+
+	f, ok := rep.w.(*os.File)
+	if !ok || f == os.Stdout {
+		return nil
+	}
+
+	err := f.Close()
+	if err != nil {
+		return errors.Wrap(err, "failed to close events file")
+	}
+
+	return nil
+}
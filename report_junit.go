@@ -0,0 +1,240 @@
+package selenium
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// JUnit XML format as understood by Jenkins and GitLab. Failures of the
+// previous attempts are reported as flakyFailure and rerunFailure elements
+// (Maven Surefire extension). Screenshots are attached via [[ATTACHMENT|path]]
+// lines in system-out (Jenkins JUnit Attachments plugin). The schema allows
+// properties only in testsuite, so tests' tags are reported there.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	Timestamp  string           `xml:"timestamp,attr,omitempty"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name          string         `xml:"name,attr"`
+	Classname     string         `xml:"classname,attr"`
+	Time          string         `xml:"time,attr"`
+	Skipped       *junitSkipped  `xml:"skipped,omitempty"`
+	Failure       *junitFailure  `xml:"failure,omitempty"`
+	FlakyFailures []junitFailure `xml:"flakyFailure,omitempty"`
+	RerunFailures []junitFailure `xml:"rerunFailure,omitempty"`
+	SystemOut     string         `xml:"system-out,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+const junitSuiteName = "go-selenium"
+
+// junitReporter writes JUnit XML report when the run ends.
+type junitReporter struct {
+	output string
+}
+
+func (rep *junitReporter) HandleEvent(e *Event) error {
+	return writeReport(e, junitReport, rep.output, writeJUnitReport)
+}
+
+func writeJUnitReport(output string, tests []*TestResult) error {
+	suite := junitTestSuite{
+		Name:  junitSuiteName,
+		Tests: len(tests),
+	}
+
+	var (
+		duration   time.Duration
+		startTime  time.Time
+		properties []junitProperty
+	)
+
+	for _, t := range tests {
+		tc := newJUnitTestCase(t)
+
+		if len(t.Tags) > 0 {
+			properties = append(properties, junitProperty{
+				Name:  "tags." + t.Name,
+				Value: strings.Join(t.Tags, ","),
+			})
+		}
+
+		switch {
+		case tc.Skipped != nil:
+			suite.Skipped++
+		case tc.Failure != nil:
+			suite.Failures++
+		}
+
+		duration += t.Duration()
+
+		for _, a := range t.Attempts {
+			if startTime.IsZero() || a.Start.Before(startTime) {
+				startTime = a.Start
+			}
+		}
+
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	suite.Time = formatJUnitDuration(duration)
+
+	if len(properties) > 0 {
+		suite.Properties = &junitProperties{Properties: properties}
+	}
+
+	if !startTime.IsZero() {
+		suite.Timestamp = startTime.Format("2006-01-02T15:04:05")
+	}
+
+	report := junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	f, err := createReportFile(output)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(xml.Header)
+	if err != nil {
+		return errors.Wrap(err, "failed to write report")
+	}
+
+	enc := xml.NewEncoder(f)
+	enc.Indent("", "  ")
+
+	err = enc.Encode(report)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode report")
+	}
+
+	return nil
+}
+
+func newJUnitTestCase(t *TestResult) junitTestCase {
+	tc := junitTestCase{
+		Name:      t.Name,
+		Classname: junitSuiteName,
+	}
+
+	if t.Status == StatusSkipped {
+		tc.Skipped = &junitSkipped{Message: t.SkipReason}
+		tc.Time = formatJUnitDuration(0)
+
+		return tc
+	}
+
+	var out strings.Builder
+
+	for i, a := range t.Attempts {
+		if len(t.Attempts) > 1 {
+			fmt.Fprintf(&out, "Attempt %d:\n", i+1)
+		}
+
+		for _, line := range a.Log {
+			fmt.Fprintln(&out, line)
+		}
+
+		files := a.screenshots()
+
+		if a.Artifacts != nil {
+			files = append(files, a.Artifacts.files()...)
+		}
+
+		for _, file := range files {
+			if abs, err := filepath.Abs(file); err == nil {
+				fmt.Fprintf(&out, "[[ATTACHMENT|%s]]\n", abs)
+			}
+		}
+
+		if !a.Failed {
+			continue
+		}
+
+		failure := newJUnitFailure(a.Failures)
+
+		switch {
+		case i == len(t.Attempts)-1:
+			tc.Failure = &failure
+		case t.Status == StatusFlaky:
+			tc.FlakyFailures = append(tc.FlakyFailures, failure)
+		default:
+			tc.RerunFailures = append(tc.RerunFailures, failure)
+		}
+	}
+
+	tc.Time = formatJUnitDuration(t.Duration())
+	tc.SystemOut = out.String()
+
+	return tc
+}
+
+func newJUnitFailure(failures []*AssertionFailure) junitFailure {
+	f := junitFailure{Type: "AssertionFailure"}
+
+	if len(failures) == 0 {
+		return f
+	}
+
+	f.Message = failures[0].Message
+
+	lines := make([]string, 0, len(failures))
+
+	for _, failure := range failures {
+		lines = append(lines, failure.String())
+	}
+
+	f.Text = strings.Join(lines, "\n")
+
+	return f
+}
+
+func formatJUnitDuration(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,144 @@
+package selenium
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteJUnitReport(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	failure := func(msg string) []*AssertionFailure {
+		return []*AssertionFailure{{Message: msg, File: "checkout.go", Line: 7}}
+	}
+
+	results := []*TestResult{
+		{
+			Name:   "checkout",
+			Tags:   []string{"smoke", "payments"},
+			Status: StatusFlaky,
+			Attempts: []*AttemptResult{
+				{
+					Start:    start,
+					Duration: time.Second,
+					Failed:   true,
+					Failures: failure("cart is empty"),
+					Log:      []string{"ERROR cart is empty"},
+				},
+				{
+					Start:    start.Add(time.Second),
+					Duration: time.Second,
+					Log:      []string{"INFO opened checkout"},
+				},
+			},
+		},
+		{
+			Name:   "login",
+			Status: StatusFailed,
+			Attempts: []*AttemptResult{{
+				Start:    start.Add(-time.Second),
+				Duration: 500 * time.Millisecond,
+				Failed:   true,
+				Failures: failure("wrong password"),
+				Steps: []*StepResult{{
+					Name:       "wrong password",
+					Status:     StatusFailed,
+					Screenshot: "login.png",
+				}},
+			}},
+		},
+		{
+			Name:       "search",
+			Status:     StatusSkipped,
+			SkipReason: notSelectedReason,
+		},
+	}
+
+	output := filepath.Join(t.TempDir(), "reports", "junit.xml")
+
+	err := writeJUnitReport(output, results)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var report junitTestSuites
+
+	err = xml.Unmarshal(data, &report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Tests != 3 || report.Failures != 1 || report.Skipped != 1 {
+		t.Errorf(
+			"unexpected totals: tests %d, failures %d, skipped %d",
+			report.Tests, report.Failures, report.Skipped,
+		)
+	}
+
+	suite := report.Suites[0]
+
+	if suite.Time != "2.500" || suite.Timestamp != "2024-05-01T09:59:59" {
+		t.Errorf(
+			"unexpected time %s or timestamp %s", suite.Time, suite.Timestamp,
+		)
+	}
+
+	if suite.Properties == nil || len(suite.Properties.Properties) != 1 ||
+		suite.Properties.Properties[0] != (junitProperty{
+			Name:  "tags.checkout",
+			Value: "smoke,payments",
+		}) {
+		t.Errorf("unexpected suite properties %+v", suite.Properties)
+	}
+
+	if n := strings.Count(string(data), "<properties>"); n != 1 {
+		t.Errorf("expected properties only in testsuite, got %d", n)
+	}
+
+	checkout := suite.TestCases[0]
+
+	if checkout.Failure != nil || len(checkout.FlakyFailures) != 1 ||
+		checkout.FlakyFailures[0].Message != "cart is empty" {
+		t.Errorf("unexpected failures of flaky test %+v", checkout)
+	}
+
+	for _, want := range []string{
+		"Attempt 1:\nERROR cart is empty\n",
+		"Attempt 2:\nINFO opened checkout\n",
+	} {
+		if !strings.Contains(checkout.SystemOut, want) {
+			t.Errorf("expected %q in system-out %q", want, checkout.SystemOut)
+		}
+	}
+
+	login := suite.TestCases[1]
+
+	if login.Failure == nil ||
+		login.Failure.Text != "checkout.go:7: wrong password" {
+		t.Errorf("unexpected failure %+v", login.Failure)
+	}
+
+	shot, err := filepath.Abs("login.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(login.SystemOut, "[[ATTACHMENT|"+shot+"]]") {
+		t.Errorf("screenshot is not attached in %q", login.SystemOut)
+	}
+
+	search := suite.TestCases[2]
+
+	if search.Skipped == nil || search.Skipped.Message != notSelectedReason {
+		t.Errorf("unexpected skipped test %+v", search)
+	}
+}
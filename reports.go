@@ -0,0 +1,245 @@
+package selenium
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+	"github.com/pkg/errors"
+)
+
+// Report formats that can be set in runner.reports.
+const (
+	junitReport  = "junit"
+	htmlReport   = "html"
+	jsonReport   = "json"
+	allureReport = "allure"
+)
+
+// defaultReportOutputs are used if the report's output is not set.
+var defaultReportOutputs = map[string]string{
+	junitReport:  "report.xml",
+	htmlReport:   "report.html",
+	jsonReport:   "events.ndjson",
+	allureReport: "allure-results",
+}
+
+// TestResult describes the outcome of the test. It is sent to reporters with
+// TestEndEvent and RunEndEvent.
+type TestResult struct {
+	Name string
+	Tags []string
+	// Status is one of StatusPassed, StatusFailed, StatusFlaky and
+	// StatusSkipped.
+	Status     string
+	SkipReason string
+	Parameters []TestParameter
+	Links      []TestLink
+	Attempts   []*AttemptResult
+}
+
+// TestParameter is the test's parameter that is set via Param.
+type TestParameter struct {
+	Name  string
+	Value string
+}
+
+// TestLink is the test's link that is set via Link.
+type TestLink struct {
+	Name string
+	URL  string
+}
+
+// AttemptResult describes a single run of the test.
+type AttemptResult struct {
+	// SessionID is empty if the session could not be created.
+	SessionID string
+	Start     time.Time
+	Duration  time.Duration
+	Failed    bool
+	Failures  []*AssertionFailure
+	// Log contains messages that are logged by the session.
+	Log   []string
+	Steps []*StepResult
+	// Befores and Afters are before and after each hooks.
+	Befores []*StepResult
+	Afters  []*StepResult
+	// Artifacts are set if the attempt failed and runner.artifacts is set.
+	Artifacts *Artifacts
+	commands  []webDriverCommand
+}
+
+// Duration returns the total duration of the test's attempts.
+func (tr *TestResult) Duration() time.Duration {
+	var d time.Duration
+
+	for _, a := range tr.Attempts {
+		d += a.Duration
+	}
+
+	return d
+}
+
+// Failures returns failures of the last attempt.
+func (tr *TestResult) Failures() []*AssertionFailure {
+	if len(tr.Attempts) == 0 {
+		return nil
+	}
+
+	return tr.Attempts[len(tr.Attempts)-1].Failures
+}
+
+// screenshots returns paths of the screenshots taken during the attempt
+// without duplicates.
+func (a *AttemptResult) screenshots() []string {
+	var files []string
+
+	seen := make(map[string]bool)
+
+	add := func(file string) {
+		if file != "" && !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+
+	for _, f := range a.Failures {
+		add(f.Screenshot)
+	}
+
+	var addSteps func(steps []*StepResult)
+
+	addSteps = func(steps []*StepResult) {
+		for _, step := range steps {
+			add(step.Screenshot)
+			addSteps(step.Steps)
+		}
+	}
+
+	addSteps(a.Befores)
+	addSteps(a.Steps)
+	addSteps(a.Afters)
+
+	if a.Artifacts != nil {
+		add(a.Artifacts.Screenshot)
+	}
+
+	return files
+}
+
+// result returns the test's result based on its attempts so far.
+func (t *test) result() *TestResult {
+	tr := &TestResult{
+		Name:       t.name,
+		Tags:       t.tags,
+		Status:     t.status(),
+		SkipReason: t.skipReason,
+		Parameters: t.params,
+		Links:      t.links,
+	}
+
+	for _, a := range t.attempts {
+		ar := &AttemptResult{
+			Start:    a.start,
+			Duration: a.duration,
+			Failed:   a.failed,
+			Failures: a.failures,
+		}
+
+		if a.s != nil {
+			ar.SessionID = a.s.id
+
+			for _, e := range a.s.logEntries() {
+				ar.Log = append(ar.Log, e.String())
+			}
+
+			ar.Steps, ar.Befores, ar.Afters = a.s.stepResults()
+			ar.Artifacts = a.s.getArtifacts()
+			ar.commands = a.s.api.commands.entries()
+		}
+
+		tr.Attempts = append(tr.Attempts, ar)
+	}
+
+	return tr
+}
+
+// status returns the test's status that is shown in reports.
+func (t *test) status() string {
+	switch {
+	case t.skipped:
+		return StatusSkipped
+	case t.failed():
+		return StatusFailed
+	case t.flaky:
+		return StatusFlaky
+	default:
+		return StatusPassed
+	}
+}
+
+// newConfigReporters creates reporters for runner.reports.
+func newConfigReporters() []Reporter {
+	if config == nil || config.Runner == nil {
+		return nil
+	}
+
+	reporters := make([]Reporter, 0, len(config.Runner.Reports))
+
+	for _, rs := range config.Runner.Reports {
+		output := rs.Output
+		if output == "" {
+			output = defaultReportOutputs[rs.Format]
+		}
+
+		switch rs.Format {
+		case junitReport:
+			reporters = append(reporters, &junitReporter{output: output})
+		case htmlReport:
+			reporters = append(reporters, &htmlReporter{output: output})
+		case jsonReport:
+			reporters = append(reporters, &jsonReporter{output: output})
+		case allureReport:
+			reporters = append(reporters, &allureReporter{output: output})
+		default:
+			logger.Errorf("Unsupported report format %q", rs.Format)
+		}
+	}
+
+	return reporters
+}
+
+// writeReport writes the report via write when the run ends.
+func writeReport(
+	e *Event, format, output string,
+	write func(output string, results []*TestResult) error,
+) error {
+	if e.Type != RunEndEvent {
+		return nil
+	}
+
+	err := write(output, e.Results)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s report", format)
+	}
+
+	logger.Infof("%s report is written to %s", format, output)
+
+	return nil
+}
+
+// createReportFile creates the report file and its directory.
+func createReportFile(output string) (*os.File, error) {
+	err := os.MkdirAll(filepath.Dir(output), 0755)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create report directory")
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create report file")
+	}
+
+	return f, nil
+}
@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/aleksslitvinovs/go-selenium/logger"
-	"github.com/fatih/color"
 )
 
 // TestFunction describes one test for the given session. It is used in
@@ -15,13 +15,46 @@ type TestFunction func(s *Session)
 
 type test struct {
 	name     string
+	tags     []string
+	params   []TestParameter
+	links    []TestLink
+	retries  *int
+	duration *time.Duration
 	fn       TestFunction
-	s        *Session
+	attempts []*testAttempt
 	hadError bool
+	skipped  bool
+	// skipReason describes why the test is skipped.
+	skipReason string
+	flaky      bool
+}
+
+const notSelectedReason = "test is not selected by tags or names"
+
+// testAttempt describes a single run of the test. Failed tests are retried
+// based on runner.retries.
+type testAttempt struct {
+	s        *Session
+	failures []*AssertionFailure
+	start    time.Time
+	duration time.Duration
+	failed   bool
 }
 
 type runner struct {
-	tests      []*test
+	tests []*test
+	// deadline is set if runner.timeout is set. Tests that have not started
+	// before the deadline are skipped.
+	deadline time.Time
+	// timedOut is set by workers that skip tests after the deadline.
+	timedOut   bool
+	timedOutMu sync.Mutex
+	// results are tests that are run via go test. Reports for them are
+	// written by EndGoTests.
+	results   []*test
+	resultsMu sync.Mutex
+	// reporters are added via AddReporter.
+	reporters  []Reporter
 	beforeAll  func()
 	beforeEach TestFunction
 	afterEach  TestFunction
@@ -39,28 +72,29 @@ func Run() {
 		MustStopClient()
 
 		for _, t := range r.tests {
-			if t.hadError {
+			if t.failed() {
 				errorCount++
 			}
 		}
 
-		if errorCount > 0 {
-			os.Exit(1)
+		timedOut := r.isTimedOut()
+
+		if timedOut {
+			logger.Errorf(
+				"Run timed out after %s", config.Runner.Timeout.Duration,
+			)
 		}
 
-		if errorCount > 0 {
-			logger.Custom(color.RedString(
-				"Failed! Success rate: %d/%d",
-				len(r.tests)-errorCount, len(r.tests),
-			))
+		status := StatusPassed
+		if errorCount > 0 || timedOut {
+			status = StatusFailed
+		}
+
+		events.endRun(status, r.tests)
 
+		if status == StatusFailed {
 			os.Exit(1)
 		}
-
-		logger.Custom(color.GreenString(
-			"Passed! Success rate: %d/%d",
-			len(r.tests)-errorCount, len(r.tests)),
-		)
 	}()
 
 	if client == nil {
@@ -80,10 +114,16 @@ func Run() {
 		return
 	}
 
+	events.startRun(true)
+
 	executeTests()
 }
 
 func executeTests() {
+	if d := config.Runner.Timeout.Duration; d > 0 {
+		r.deadline = time.Now().Add(d)
+	}
+
 	runBeforeAll()
 
 	pr := config.Runner.ParallelRuns
@@ -102,10 +142,16 @@ func executeTests() {
 		go worker(jobs, wg)
 	}
 
+	filter := newTestFilter()
+
 	for _, t := range r.tests {
-		wg.Add(1)
+		if !filter.selects(t) {
+			t.skip(notSelectedReason)
+
+			continue
+		}
 
-		logger.Infof("running test: %s", t.name)
+		wg.Add(1)
 
 		jobs <- t
 	}
@@ -124,50 +170,440 @@ func worker(tc <-chan *test, wg *sync.WaitGroup) {
 func runTest(t *test, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	defer handleTestPanic(t)
+	if !r.deadline.IsZero() && time.Now().After(r.deadline) {
+		r.setTimedOut()
+
+		t.skip("run timed out")
+
+		return
+	}
+
+	executeTest(t, nil)
+}
+
+func (r *runner) setTimedOut() {
+	r.timedOutMu.Lock()
+	defer r.timedOutMu.Unlock()
+
+	r.timedOut = true
+}
+
+func (r *runner) isTimedOut() bool {
+	r.timedOutMu.Lock()
+	defer r.timedOutMu.Unlock()
+
+	return r.timedOut
+}
+
+// executeTest runs the test until it passes or the retries are exhausted. Each
+// attempt is run in a new session. If cleanup is set, the final attempt's
+// session is closed via cleanup instead of when the attempt ends.
+func executeTest(t *test, cleanup func(func())) {
+	retries := t.retryCount()
+
+	t.emit(&Event{Type: TestStartEvent})
+
+	defer t.end()
+
+	for i := 0; i <= retries; i++ {
+		if i > 0 {
+			t.emit(&Event{
+				Type:    RetryEvent,
+				Attempt: i + 1,
+				Message: fmt.Sprintf("attempt %d of %d", i+1, retries+1),
+			})
+		}
+
+		executeAttempt(t, i+1, cleanup)
+
+		if !t.hadError {
+			t.flaky = i > 0
+
+			return
+		}
+	}
+}
+
+// end sends TestEndEvent with the test's result.
+func (t *test) end() {
+	tr := t.result()
+
+	e := &Event{
+		Type:    TestEndEvent,
+		Attempt: len(tr.Attempts),
+		Status:  tr.Status,
+		Message: tr.SkipReason,
+		Elapsed: tr.Duration().Seconds(),
+		Result:  tr,
+	}
+
+	if n := len(tr.Attempts); n > 0 {
+		e.SessionID = tr.Attempts[n-1].SessionID
+		e.Artifacts = tr.Attempts[n-1].Artifacts
+	}
+
+	t.emit(e)
+}
+
+// executeAttempt runs the test in a new session. If the test has a timeout,
+// it is run in a separate goroutine. If the test does not finish within its
+// timeout, the session is cancelled and deleted, and the runner moves on
+// without waiting for the test.
+func executeAttempt(t *test, attempt int, cleanup func(func())) {
+	startTime := time.Now()
+
+	started := make(chan *Session, 1)
+	done := make(chan *AssertionFailure, 1)
+
+	timeout := t.timeout()
+	if timeout <= 0 {
+		// The attempt is recorded even if the test exits via runtime.Goexit.
+		defer func() {
+			finishAttempt(t, startedSession(started), <-done, startTime)
+		}()
+
+		callAttempt(t, attempt, started, cleanup, done)
+
+		return
+	}
+
+	go callAttempt(t, attempt, started, cleanup, done)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var (
+		s       *Session
+		failure *AssertionFailure
+	)
+
+	select {
+	case failure = <-done:
+		s = startedSession(started)
+	case <-timer.C:
+		s = startedSession(started)
+		failure = handleTestTimeout(t, s, timeout)
+
+		if s == nil {
+			go deleteLateSession(started, done)
+		}
+	}
+
+	finishAttempt(t, s, failure, startTime)
+}
+
+// callAttempt runs the attempt and sends its failure to done. The failure is
+// sent even if the test exits via runtime.Goexit, e.g., when t.FailNow is
+// called, in which case the attempt is failed.
+func callAttempt(
+	t *test,
+	attempt int,
+	started chan<- *Session,
+	cleanup func(func()),
+	done chan<- *AssertionFailure,
+) {
+	var (
+		failure  *AssertionFailure
+		returned bool
+	)
+
+	defer func() {
+		if !returned {
+			failure = &AssertionFailure{
+				Message: fmt.Sprintf("test %q exited before finishing", t.name),
+				Time:    time.Now(),
+			}
+		}
+
+		done <- failure
+	}()
+
+	failure = runAttempt(t, attempt, started, cleanup)
+	returned = true
+}
+
+// deleteLateSession deletes the session of the timed out attempt that is
+// created after the timeout, as the runner has already moved on.
+func deleteLateSession(
+	started <-chan *Session, done <-chan *AssertionFailure,
+) {
+	var s *Session
+
+	select {
+	case s = <-started:
+	case <-done:
+		// The session is sent before the attempt is done.
+		s = startedSession(started)
+	}
+
+	if s == nil {
+		return
+	}
+
+	s.cancel()
+
+	deleteCancelledSession(s, s.detached())
+}
+
+// runAttempt runs the test with before and after each hooks in a new session.
+// The session is sent to started as soon as it is created. Raised failure is
+// returned.
+func runAttempt(
+	t *test, attempt int, started chan<- *Session, cleanup func(func()),
+) (failure *AssertionFailure) {
+	defer handleTestPanic(&failure)
 
 	s, err := NewSession()
 	if err != nil {
 		panic(err)
 	}
 
-	t.s = s
+	s.test = t.name
+	s.attempt = attempt
 
-	client.ss.mu.Lock()
-	client.ss.sessions[s] = true
-	client.ss.mu.Unlock()
+	started <- s
+
+	if cleanup != nil {
+		cleanup(func() { closeTestSession(s) })
+	} else {
+		defer closeTestSession(s)
+	}
+
+	failure = runTestFunction(t, s)
+	failed := failure != nil || len(s.Failures()) > 0
+
+	// Artifacts are collected before the session is closed.
+	if failed && !s.isCancelled() {
+		a := s.saveFailureArtifacts(s)
+		if a != nil && failure != nil && failure.Screenshot == "" {
+			failure.Screenshot = a.Screenshot
+		}
+	}
+
+	// Only the final attempt's session is left to cleanup. Closed session is
+	// cancelled, therefore, it is not closed again during cleanup.
+	if cleanup != nil && failed && attempt <= t.retryCount() {
+		closeTestSession(s)
+	}
 
-	defer s.DeleteSession()
+	return failure
+}
+
+// runTestFunction runs the test with before and after each hooks. Raised
+// failure is returned.
+func runTestFunction(t *test, s *Session) (failure *AssertionFailure) {
+	defer handleTestPanic(&failure)
 
 	runBeforeEach(s)
 
-	t.fn(t.s)
+	t.fn(s)
 
 	runAfterEach(s)
+
+	return nil
 }
 
-func handleTestPanic(t *test) {
-	err := recover()
-	if err == nil {
-		return
+func startedSession(started <-chan *Session) *Session {
+	select {
+	case s := <-started:
+		return s
+	default:
+		return nil
 	}
+}
 
-	t.hadError = true
-
-	if t.s == nil {
+func handleTestPanic(failure **AssertionFailure) {
+	err := recover()
+	if err == nil {
 		return
 	}
 
 	switch v := err.(type) {
+	case *AssertionFailure:
+		*failure = v
 	case error:
-		t.s.AddError(v.Error())
+		*failure = newFailure(v.Error())
 	case string:
-		t.s.AddError(v)
+		*failure = newFailure(v)
 	default:
-		t.s.AddError(fmt.Sprintf("%v", v))
+		*failure = newFailure(fmt.Sprintf("%v", v))
 	}
 }
 
+// handleTestTimeout cancels the session's requests, collects failure artifacts
+// or takes a screenshot and deletes the session. Timeout failure is returned.
+func handleTestTimeout(
+	t *test, s *Session, timeout time.Duration,
+) *AssertionFailure {
+	f := &AssertionFailure{
+		Message: fmt.Sprintf("test %q timed out after %s", t.name, timeout),
+		Time:    time.Now(),
+	}
+
+	logger.Error(f.Message)
+
+	if s == nil {
+		return f
+	}
+
+	s.cancel()
+
+	d := s.detached()
+
+	if a := s.saveFailureArtifacts(d); a != nil {
+		f.Screenshot = a.Screenshot
+	}
+
+	if f.Screenshot == "" {
+		f.Screenshot = d.failureScreenshot()
+	}
+
+	deleteCancelledSession(s, d)
+
+	return f
+}
+
+// deleteCancelledSession deletes the cancelled session s via its detached
+// copy d and removes both from the client.
+func deleteCancelledSession(s, d *Session) {
+	_, err := callCondition(d.checking(func() (bool, error) {
+		d.DeleteSession()
+
+		return true, nil
+	}))
+	if err != nil {
+		logger.Errorf("Failed to delete timed out session: %s", err)
+	}
+
+	client.ss.mu.Lock()
+	defer client.ss.mu.Unlock()
+
+	delete(client.ss.sessions, s)
+	delete(client.ss.sessions, d)
+}
+
+// finishAttempt records the attempt. The test is marked as failed if the
+// attempt raised a failure or there were soft assertion failures.
+func finishAttempt(
+	t *test, s *Session, failure *AssertionFailure, startTime time.Time,
+) {
+	a := &testAttempt{
+		s:        s,
+		start:    startTime,
+		duration: time.Since(startTime),
+	}
+
+	if s != nil {
+		a.failures = append(a.failures, s.Failures()...)
+	}
+
+	if failure != nil {
+		if s == nil {
+			logger.Errorf("Failed to start test %q: %s", t.name, failure)
+		}
+
+		a.failures = append(a.failures, failure)
+	}
+
+	a.failed = len(a.failures) > 0
+	t.hadError = a.failed
+	t.attempts = append(t.attempts, a)
+}
+
+func (t *test) skip(reason string) {
+	t.skipped = true
+	t.skipReason = reason
+
+	logger.Infof("skipping test: %s (%s)", t.name, reason)
+
+	t.end()
+}
+
+// retryCount returns the number of times the failed test is retried. Test's
+// own setting takes precedence over runner.retries. Negative values are
+// treated as 0, so that the test is run at least once.
+func (t *test) retryCount() int {
+	retries := 0
+
+	if t.retries != nil {
+		retries = *t.retries
+	} else if config != nil && config.Runner != nil {
+		retries = config.Runner.Retries
+	}
+
+	if retries < 0 {
+		return 0
+	}
+
+	return retries
+}
+
+// timeout returns the time within which the test must finish. Test's own
+// setting takes precedence over runner.test_timeout. Test is not allowed to
+// run past runner.timeout. Zero means there is no timeout.
+func (t *test) timeout() time.Duration {
+	var timeout time.Duration
+
+	if t.duration != nil {
+		timeout = *t.duration
+	} else if config != nil && config.Runner != nil {
+		timeout = config.Runner.TestTimeout.Duration
+	}
+
+	if r.deadline.IsZero() {
+		return timeout
+	}
+
+	remaining := time.Until(r.deadline)
+	if timeout <= 0 || remaining < timeout {
+		// Timeout must be positive as zero means there is no timeout.
+		if remaining <= 0 {
+			remaining = time.Nanosecond
+		}
+
+		return remaining
+	}
+
+	return timeout
+}
+
+// failed checks if the test is failed. Test that is not skipped and has no
+// attempts, e.g., because it was interrupted, is failed as well.
+func (t *test) failed() bool {
+	if t.skipped {
+		return false
+	}
+
+	return t.hadError || len(t.attempts) == 0
+}
+
+// failures returns failures of the last attempt.
+func (t *test) failures() []*AssertionFailure {
+	if len(t.attempts) == 0 {
+		return nil
+	}
+
+	return t.attempts[len(t.attempts)-1].failures
+}
+
+// closeTestSession deletes the session and removes it from the client, as its
+// failures are reported by the runner instead of StopClient. Cancelled sessions
+// are already deleted by the runner.
+func closeTestSession(s *Session) {
+	if s.isCancelled() {
+		return
+	}
+
+	defer func() {
+		client.ss.mu.Lock()
+		defer client.ss.mu.Unlock()
+
+		delete(client.ss.sessions, s)
+	}()
+
+	s.DeleteSession()
+}
+
 func runBeforeAll() {
 	if r.beforeAll != nil {
 		r.beforeAll()
@@ -176,13 +612,13 @@ func runBeforeAll() {
 
 func runBeforeEach(s *Session) {
 	if r.beforeEach != nil {
-		r.beforeEach(s)
+		s.runFixture(&s.befores, "before each", r.beforeEach)
 	}
 }
 
 func runAfterEach(s *Session) {
 	if r.afterEach != nil {
-		r.afterEach(s)
+		s.runFixture(&s.afters, "after each", r.afterEach)
 	}
 }
 
@@ -212,32 +648,99 @@ func SetAfterAll(fn func()) {
 	r.afterAll = fn
 }
 
+// TestOption configures the test that is set via SetTestWithOptions.
+type TestOption func(t *test)
+
+// Name sets the name that is used to identify the test.
+func Name(name string) TestOption {
+	return func(t *test) {
+		t.name = name
+	}
+}
+
+// Tags sets the test's tags that can be used to select which tests are run.
+// See runner.tags in the config.
+func Tags(tags ...string) TestOption {
+	return func(t *test) {
+		t.tags = append(t.tags, tags...)
+	}
+}
+
+// Param adds the parameter that is shown in reports, e.g., the browser or the
+// data set the test is run with.
+func Param(name string, value interface{}) TestOption {
+	return func(t *test) {
+		t.params = append(t.params, TestParameter{
+			Name:  name,
+			Value: fmt.Sprint(value),
+		})
+	}
+}
+
+// Link adds the link that is shown in reports, e.g., to the issue or the test
+// case in the tracker.
+func Link(name, url string) TestOption {
+	return func(t *test) {
+		t.links = append(t.links, TestLink{Name: name, URL: url})
+	}
+}
+
+// Retries sets the number of times the test is retried if it fails. It
+// overrides runner.retries from the config. Negative values are set to 0.
+func Retries(retries int) TestOption {
+	if retries < 0 {
+		logger.Warnf("Retries(%d) is less than 0. Setting it to 0.", retries)
+
+		retries = 0
+	}
+
+	return func(t *test) {
+		t.retries = &retries
+	}
+}
+
+// Timeout sets the time within which the test must finish. It overrides
+// runner.test_timeout from the config.
+func Timeout(timeout time.Duration) TestOption {
+	return func(t *test) {
+		t.duration = &timeout
+	}
+}
+
 // SetTest sets the test function. The name is used to identify the test is
 // optional. If no name is provided, test_<test_id> is used. If the given name
 // is already in use, test ID is appended to the name.
 func SetTest(fn TestFunction, name ...string) {
-	if len(name) == 0 {
-		r.tests = append(r.tests, &test{
-			name: fmt.Sprintf("test_%d", len(r.tests)),
-			fn:   fn,
-		})
+	var opts []TestOption
 
-		return
+	if len(name) > 0 {
+		opts = append(opts, Name(name[0]))
 	}
 
-	for _, t := range r.tests {
-		if t.name == name[0] {
-			r.tests = append(r.tests, &test{
-				name: fmt.Sprintf("%s_%d", name[0], len(r.tests)),
-				fn:   fn,
-			})
+	SetTestWithOptions(fn, opts...)
+}
 
-			return
+// SetTestWithOptions sets the test function configured with the given
+// options, e.g., selenium.Name("checkout") or selenium.Tags("smoke"). Test's
+// name is set the same way as in SetTest.
+func SetTestWithOptions(fn TestFunction, opts ...TestOption) {
+	t := &test{fn: fn}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	if t.name == "" {
+		t.name = fmt.Sprintf("test_%d", len(r.tests))
+	}
+
+	for _, other := range r.tests {
+		if other.name == t.name {
+			t.name = fmt.Sprintf("%s_%d", t.name, len(r.tests))
+
+			break
 		}
 	}
 
-	r.tests = append(r.tests, &test{
-		name: name[0],
-		fn:   fn,
-	})
+	r.tests = append(r.tests, t)
 }
@@ -0,0 +1,153 @@
+package selenium
+
+import (
+	"flag"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+)
+
+// Environment variables and flags that override runner.tags,
+// runner.exclude_tags and runner.names from the config. Tags are separated by
+// commas, tags prefixed with "!" are excluded, e.g., "smoke,!slow". Names are
+// glob patterns separated by commas, e.g., "checkout*,login".
+const (
+	tagsEnv   = "GOSELENIUM_TAGS"
+	namesEnv  = "GOSELENIUM_NAMES"
+	tagsFlag  = "selenium.tags"
+	namesFlag = "selenium.names"
+)
+
+// filterFlags are values of the flags that are registered via RegisterFlags.
+var filterFlags struct {
+	tags  string
+	names string
+}
+
+// RegisterFlags registers -selenium.tags and -selenium.names flags that
+// override runner.tags, runner.exclude_tags and runner.names from the config
+// and the environment variables. Flags must be registered before they are
+// parsed, e.g., seleniumtest.Main registers them in flag.CommandLine.
+func RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&filterFlags.tags, tagsFlag, "",
+		`run tests with the given tags, e.g., "smoke,!slow"`,
+	)
+	fs.StringVar(
+		&filterFlags.names, namesFlag, "",
+		`run tests whose names match the given globs, e.g., "checkout*"`,
+	)
+}
+
+// testFilter selects tests based on their tags and names.
+type testFilter struct {
+	tags        []string
+	excludeTags []string
+	names       []string
+}
+
+// newTestFilter returns a filter based on the config. Environment variables
+// override the config, and flags registered via RegisterFlags override
+// environment variables.
+func newTestFilter() *testFilter {
+	f := &testFilter{}
+
+	if config != nil && config.Runner != nil {
+		f.tags = config.Runner.Tags
+		f.excludeTags = config.Runner.ExcludeTags
+		f.names = config.Runner.Names
+	}
+
+	if v, ok := os.LookupEnv(tagsEnv); ok {
+		f.setTags(v)
+	}
+
+	if v, ok := os.LookupEnv(namesEnv); ok {
+		f.names = splitList(v)
+	}
+
+	if filterFlags.tags != "" {
+		f.setTags(filterFlags.tags)
+	}
+
+	if filterFlags.names != "" {
+		f.names = splitList(filterFlags.names)
+	}
+
+	return f
+}
+
+// setTags parses tags expression, e.g., "smoke,!slow".
+func (f *testFilter) setTags(expr string) {
+	f.tags = nil
+	f.excludeTags = nil
+
+	for _, tag := range splitList(expr) {
+		if strings.HasPrefix(tag, "!") {
+			f.excludeTags = append(f.excludeTags, strings.TrimPrefix(tag, "!"))
+
+			continue
+		}
+
+		f.tags = append(f.tags, tag)
+	}
+}
+
+// selects checks if the test should be run. Test is run if its name matches
+// any of the names, it has any of the tags and has none of the excluded tags.
+// Empty names and tags match all tests.
+func (f *testFilter) selects(t *test) bool {
+	if len(f.names) > 0 && !matchesAny(t.name, f.names) {
+		return false
+	}
+
+	if len(f.tags) > 0 && !hasAny(t.tags, f.tags) {
+		return false
+	}
+
+	return !hasAny(t.tags, f.excludeTags)
+}
+
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		ok, err := path.Match(pattern, name)
+		if err != nil {
+			logger.Warnf("Invalid test name pattern %q: %s", pattern, err)
+
+			continue
+		}
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasAny(tags, expected []string) bool {
+	for _, tag := range tags {
+		for _, e := range expected {
+			if tag == e {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func splitList(v string) []string {
+	var items []string
+
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
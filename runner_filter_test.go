@@ -0,0 +1,149 @@
+package selenium
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestTestFilterSelects(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *testFilter
+		test   *test
+		want   bool
+	}{
+		{
+			name:   "empty filter",
+			filter: &testFilter{},
+			test:   &test{name: "checkout"},
+			want:   true,
+		},
+		{
+			name:   "matching tag",
+			filter: &testFilter{tags: []string{"smoke"}},
+			test: &test{
+				name: "checkout",
+				tags: []string{"payments", "smoke"},
+			},
+			want: true,
+		},
+		{
+			name:   "missing tag",
+			filter: &testFilter{tags: []string{"smoke"}},
+			test:   &test{name: "checkout", tags: []string{"payments"}},
+		},
+		{
+			name: "excluded tag",
+			filter: &testFilter{
+				tags:        []string{"smoke"},
+				excludeTags: []string{"slow"},
+			},
+			test: &test{name: "checkout", tags: []string{"smoke", "slow"}},
+		},
+		{
+			name:   "matching name glob",
+			filter: &testFilter{names: []string{"login", "check*"}},
+			test:   &test{name: "checkout"},
+			want:   true,
+		},
+		{
+			name:   "not matching name glob",
+			filter: &testFilter{names: []string{"login*"}},
+			test:   &test{name: "checkout"},
+		},
+		{
+			name:   "invalid name glob",
+			filter: &testFilter{names: []string{"[checkout"}},
+			test:   &test{name: "checkout"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.selects(tt.test); got != tt.want {
+				t.Errorf("selects() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTestFilterPrecedence(t *testing.T) {
+	c := setTestConfig(t)
+	c.Runner.Tags = []string{"config"}
+	c.Runner.Names = []string{"config*"}
+
+	t.Setenv(tagsEnv, "smoke, !slow")
+	t.Setenv(namesEnv, "env*")
+
+	f := newTestFilter()
+
+	if !equalStrings(f.tags, []string{"smoke"}) ||
+		!equalStrings(f.excludeTags, []string{"slow"}) ||
+		!equalStrings(f.names, []string{"env*"}) {
+		t.Errorf("environment variables are not applied: %+v", f)
+	}
+
+	t.Cleanup(func() {
+		filterFlags.tags = ""
+		filterFlags.names = ""
+	})
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	RegisterFlags(fs)
+
+	err := fs.Parse([]string{"-selenium.tags=!flaky", "-selenium.names=flag*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f = newTestFilter()
+
+	if len(f.tags) != 0 ||
+		!equalStrings(f.excludeTags, []string{"flaky"}) ||
+		!equalStrings(f.names, []string{"flag*"}) {
+		t.Errorf("flags are not applied: %+v", f)
+	}
+}
+
+func TestSetTestWithOptions(t *testing.T) {
+	previous := r.tests
+	r.tests = nil
+
+	t.Cleanup(func() {
+		r.tests = previous
+	})
+
+	SetTest(nil)
+	SetTest(nil, "checkout")
+	SetTestWithOptions(nil, Name("checkout"), Tags("smoke"), Tags("payments"))
+
+	names := make([]string, 0, len(r.tests))
+
+	for _, tc := range r.tests {
+		names = append(names, tc.name)
+	}
+
+	if !equalStrings(names, []string{"test_0", "checkout", "checkout_2"}) {
+		t.Errorf("unexpected test names %v", names)
+	}
+
+	if !equalStrings(r.tests[2].tags, []string{"smoke", "payments"}) {
+		t.Errorf("unexpected tags %v", r.tests[2].tags)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
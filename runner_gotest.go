@@ -0,0 +1,168 @@
+package selenium
+
+import (
+	"sync"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+)
+
+// TestingT is the part of *testing.T that is used to run tests via go test.
+// The library does not import the testing package, use package seleniumtest to
+// run the tests from Go test functions.
+type TestingT interface {
+	Helper()
+	Name() string
+	Cleanup(f func())
+	Parallel()
+	Log(args ...interface{})
+	Logf(format string, args ...interface{})
+	Error(args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Skip(args ...interface{})
+	Fail()
+	Failed() bool
+}
+
+// EndGoTests writes runner.reports for tests run via RunSubtests and
+// RunGoTest, and stops the client. Passed is the result of the Go tests. It is
+// called by seleniumtest.Main.
+func EndGoTests(passed bool) {
+	status := StatusPassed
+	if !passed {
+		status = StatusFailed
+	}
+
+	events.endRun(status, r.results)
+
+	if client != nil {
+		err := StopClient()
+		if err != nil {
+			logger.Error(err)
+		}
+	}
+}
+
+// RunSubtests executes all tests set via SetTest as subtests of t that are
+// started via run. Test failures are reported via t.Error. If
+// runner.parallel_runs is greater than 1, subtests are run in parallel. Before
+// all and after all hooks are run before and after the subtests. Tests that
+// are not selected by runner.tags, runner.exclude_tags and runner.names are
+// skipped. Set tests are cleared, so that the tests can be run from multiple
+// Go test functions. It is called by seleniumtest.RunT.
+func RunSubtests(
+	t TestingT, run func(name string, subtest func(t TestingT)),
+) {
+	t.Helper()
+
+	tests := r.tests
+	r.tests = nil
+
+	ensureClient(t)
+	events.startRun(false)
+
+	t.Cleanup(func() {
+		r.resultsMu.Lock()
+		defer r.resultsMu.Unlock()
+
+		r.results = append(r.results, tests...)
+	})
+
+	runBeforeAll()
+	t.Cleanup(runAfterAll)
+
+	filter := newTestFilter()
+
+	for _, tc := range tests {
+		tc := tc
+
+		run(tc.name, func(t TestingT) {
+			if !filter.selects(tc) {
+				tc.skip(notSelectedReason)
+
+				t.Skip(notSelectedReason)
+			}
+
+			if config.Runner.ParallelRuns > 1 {
+				t.Parallel()
+			}
+
+			runT(t, tc)
+		})
+	}
+}
+
+// RunGoTest executes fn as the Go test t in a new session. Before each and
+// after each hooks are run. Failures are reported via t.Error. It is called by
+// seleniumtest.Test.
+func RunGoTest(t TestingT, fn TestFunction) {
+	t.Helper()
+
+	ensureClient(t)
+	events.startRun(false)
+
+	tc := &test{name: t.Name(), fn: fn}
+
+	t.Cleanup(func() {
+		r.resultsMu.Lock()
+		defer r.resultsMu.Unlock()
+
+		r.results = append(r.results, tc)
+	})
+
+	runT(t, tc)
+}
+
+func runT(t TestingT, tc *test) {
+	t.Helper()
+
+	executeTest(tc, t.Cleanup)
+
+	if tc.flaky {
+		t.Logf("test is flaky, passed on attempt %d", len(tc.attempts))
+
+		for i, a := range tc.attempts {
+			if !a.failed {
+				continue
+			}
+
+			t.Logf("attempt %d failed:", i+1)
+
+			for _, f := range a.failures {
+				t.Log(f.String())
+			}
+		}
+	}
+
+	for _, f := range tc.failures() {
+		t.Error(f.String())
+	}
+
+	if n := len(tc.attempts); n > 0 && tc.attempts[n-1].s != nil {
+		if a := tc.attempts[n-1].s.getArtifacts(); a != nil {
+			t.Logf("failure artifacts: %s", a.Dir)
+		}
+	}
+
+	if tc.failed() && !t.Failed() {
+		t.Fail()
+	}
+}
+
+// clientMu prevents parallel Go tests from setting the client concurrently.
+var clientMu sync.Mutex
+
+func ensureClient(t TestingT) {
+	t.Helper()
+
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if client != nil {
+		return
+	}
+
+	err := SetClient(nil, nil)
+	if err != nil {
+		t.Fatalf("failed to set client: %s", err)
+	}
+}
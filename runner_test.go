@@ -0,0 +1,384 @@
+package selenium
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  int
+		options []TestOption
+		want    int
+	}{
+		{name: "config", config: 2, want: 2},
+		{
+			name:    "option overrides config",
+			config:  2,
+			options: []TestOption{Retries(0)},
+			want:    0,
+		},
+		{name: "negative config", config: -1, want: 0},
+		{
+			name:    "negative option",
+			config:  2,
+			options: []TestOption{Retries(-3)},
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t).Runner.Retries = tt.config
+
+			tc := &test{}
+			for _, opt := range tt.options {
+				opt(tc)
+			}
+
+			if got := tc.retryCount(); got != tt.want {
+				t.Errorf("retryCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteTestRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		retries      int
+		failures     int
+		wantAttempts int
+		wantStatus   string
+	}{
+		{name: "passed", retries: 2, wantAttempts: 1, wantStatus: StatusPassed},
+		{
+			name:         "flaky",
+			retries:      2,
+			failures:     2,
+			wantAttempts: 3,
+			wantStatus:   StatusFlaky,
+		},
+		{
+			name:         "failed",
+			retries:      1,
+			failures:     3,
+			wantAttempts: 2,
+			wantStatus:   StatusFailed,
+		},
+		{
+			name:         "negative retries",
+			retries:      -1,
+			failures:     1,
+			wantAttempts: 1,
+			wantStatus:   StatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t).Runner.Retries = tt.retries
+			setFakeClient(t, nil)
+
+			runs := 0
+
+			tc := &test{name: tt.name, fn: func(s *Session) {
+				runs++
+
+				if runs <= tt.failures {
+					panic(fmt.Sprintf("attempt %d failed", runs))
+				}
+			}}
+
+			executeTest(tc, nil)
+
+			tr := tc.result()
+
+			if len(tr.Attempts) != tt.wantAttempts {
+				t.Errorf(
+					"expected %d attempts, got %d",
+					tt.wantAttempts, len(tr.Attempts),
+				)
+			}
+
+			if tr.Status != tt.wantStatus {
+				t.Errorf("expected %s, got %s", tt.wantStatus, tr.Status)
+			}
+
+			for i, a := range tr.Attempts {
+				if wantFailed := i < tt.failures; a.Failed != wantFailed {
+					t.Errorf("attempt %d: failed = %t", i+1, a.Failed)
+				}
+			}
+		})
+	}
+}
+
+func TestExecuteTestClosesRetriedSessions(t *testing.T) {
+	setTestConfig(t).Runner.Retries = 2
+	setFakeClient(t, nil)
+
+	var sessions []*Session
+
+	tc := &test{name: "flaky", fn: func(s *Session) {
+		sessions = append(sessions, s)
+
+		if len(sessions) < 3 {
+			panic(fmt.Sprintf("attempt %d failed", len(sessions)))
+		}
+	}}
+
+	var cleanups []func()
+
+	executeTest(tc, func(fn func()) { cleanups = append(cleanups, fn) })
+
+	if len(sessions) != 3 || len(cleanups) != 3 {
+		t.Fatalf(
+			"expected 3 attempts, got %d sessions and %d cleanups",
+			len(sessions), len(cleanups),
+		)
+	}
+
+	for i, s := range sessions {
+		if closed := s.isCancelled(); closed != (i < 2) {
+			t.Errorf("attempt %d: session is closed: %t", i+1, closed)
+		}
+	}
+
+	for _, fn := range cleanups {
+		fn()
+	}
+
+	if !sessions[2].isCancelled() || len(client.ss.sessions) != 0 {
+		t.Errorf("final session is not closed during cleanup")
+	}
+
+	if n := requests(sessions[0], "DELETE /session/fake"); n != 1 {
+		t.Errorf("retried session is deleted %d times", n)
+	}
+}
+
+func TestExecuteAttemptGoexit(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		// wantAttempts is 1 if the test is run inline, as Goexit stops the
+		// runner's goroutine as well.
+		wantAttempts int
+	}{
+		{name: "without timeout", wantAttempts: 1},
+		{name: "with timeout", timeout: time.Second, wantAttempts: 2},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t)
+			setFakeClient(t, nil)
+
+			runs := 0
+
+			tc := &test{name: tt.name, fn: func(s *Session) {
+				runs++
+
+				if runs == 1 {
+					runtime.Goexit()
+				}
+			}}
+			Retries(1)(tc)
+			Timeout(tt.timeout)(tc)
+
+			finished := make(chan struct{})
+
+			go func() {
+				defer close(finished)
+
+				executeTest(tc, nil)
+			}()
+
+			<-finished
+
+			if len(tc.attempts) != tt.wantAttempts {
+				t.Fatalf(
+					"expected %d attempts, got %d",
+					tt.wantAttempts, len(tc.attempts),
+				)
+			}
+
+			failures := tc.attempts[0].failures
+			if len(failures) != 1 ||
+				!strings.Contains(failures[0].Message, "exited") {
+				t.Errorf("unexpected failures %v", failures)
+			}
+		})
+	}
+}
+
+func TestExecuteAttemptDeletesLateSession(t *testing.T) {
+	setTestConfig(t)
+	setFakeClient(t, map[string]fakeResponse{
+		"POST /session": {
+			value: map[string]string{"sessionId": "fake"},
+			delay: 100 * time.Millisecond,
+		},
+	})
+
+	cancelled := make(chan bool, 1)
+
+	tc := &test{name: "late", fn: func(s *Session) {
+		select {
+		case <-s.api.ctx.Done():
+			cancelled <- true
+		case <-time.After(time.Second):
+			cancelled <- false
+		}
+	}}
+	Timeout(20 * time.Millisecond)(tc)
+
+	executeTest(tc, nil)
+
+	if tc.status() != StatusFailed || tc.attempts[0].s != nil {
+		t.Fatalf("expected the attempt to time out before the session starts")
+	}
+
+	if !<-cancelled {
+		t.Fatal("session created after the timeout is not cancelled")
+	}
+
+	for end := time.Now().Add(time.Second); ; {
+		client.ss.mu.Lock()
+		n := len(client.ss.sessions)
+		client.ss.mu.Unlock()
+
+		if n == 0 {
+			break
+		}
+
+		if time.Now().After(end) {
+			t.Fatalf("session created after the timeout is not deleted")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRunTestAfterDeadline(t *testing.T) {
+	setTestConfig(t)
+
+	r.deadline = time.Now().Add(-time.Second)
+
+	t.Cleanup(func() {
+		r.deadline = time.Time{}
+		r.timedOut = false
+	})
+
+	tests := []*test{{name: "a"}, {name: "b"}, {name: "c"}}
+
+	wg := &sync.WaitGroup{}
+
+	for _, tc := range tests {
+		wg.Add(1)
+
+		go runTest(tc, wg)
+	}
+
+	wg.Wait()
+
+	if !r.isTimedOut() {
+		t.Error("run is not timed out")
+	}
+
+	for _, tc := range tests {
+		if tc.status() != StatusSkipped {
+			t.Errorf("test %s is not skipped", tc.name)
+		}
+	}
+}
+
+func TestStatusWithoutAttempts(t *testing.T) {
+	if got := (&test{}).status(); got != StatusFailed {
+		t.Errorf("expected %s, got %s", StatusFailed, got)
+	}
+
+	tc := &test{skipped: true}
+	if got := tc.status(); got != StatusSkipped {
+		t.Errorf("expected %s, got %s", StatusSkipped, got)
+	}
+}
+
+// fakeT records what is logged and reported via TestingT.
+type fakeT struct {
+	logs   []string
+	errors []string
+	failed bool
+}
+
+func (f *fakeT) Helper()           {}
+func (f *fakeT) Name() string      { return "fake" }
+func (f *fakeT) Cleanup(fn func()) {}
+func (f *fakeT) Parallel()         {}
+
+func (f *fakeT) Log(args ...interface{}) {
+	f.logs = append(f.logs, fmt.Sprint(args...))
+}
+
+func (f *fakeT) Logf(format string, args ...interface{}) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Error(args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprint(args...))
+	f.failed = true
+}
+
+func (f *fakeT) Fatalf(format string, args ...interface{}) {
+	panic(fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Skip(args ...interface{}) {}
+func (f *fakeT) Fail()                    { f.failed = true }
+func (f *fakeT) Failed() bool             { return f.failed }
+
+func TestRunTLogsFlakyAttempts(t *testing.T) {
+	setTestConfig(t).Runner.Retries = 2
+	setFakeClient(t, nil)
+
+	runs := 0
+
+	tc := &test{name: "flaky", fn: func(s *Session) {
+		runs++
+
+		if runs < 3 {
+			panic(fmt.Sprintf("attempt %d failed", runs))
+		}
+	}}
+
+	ft := &fakeT{}
+	runT(ft, tc)
+
+	if ft.failed {
+		t.Errorf("flaky test is reported as failed: %v", ft.errors)
+	}
+
+	logs := strings.Join(ft.logs, "\n")
+
+	for _, want := range []string{
+		"passed on attempt 3",
+		"attempt 1 failed",
+		"attempt 2 failed",
+	} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("expected %q to be logged, got:\n%s", want, logs)
+		}
+	}
+}
@@ -63,11 +63,13 @@ func UltimateQAForm(s *selenium.Session) {
 
 	fillCatpcha(s)
 
+	s.TrackRequests()
+
 	s.NewElement("#et_pb_contact_form_1 .et_pb_button").
 		WaitFor(10 * time.Second).UntilIsVisible().
 		Click()
 
-	time.Sleep(5 * time.Second)
+	s.WaitFor(10 * time.Second).UntilNoPendingXHR()
 
 	s.NewElement("#et_pb_contact_form_1 .et-pb-contact-message p").
 		WaitFor(10 * time.Second).UntilIsVisible().
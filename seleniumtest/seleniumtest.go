@@ -0,0 +1,66 @@
+// Package seleniumtest runs go-selenium tests via go test, so that each test is
+// reported, filtered (-run) and timed by go test individually.
+//
+//	func TestMain(m *testing.M) {
+//		seleniumtest.Main(m)
+//	}
+//
+//	func TestSearch(t *testing.T) {
+//		selenium.SetTest(SearchTest, "search")
+//
+//		seleniumtest.RunT(t)
+//	}
+package seleniumtest
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/aleksslitvinovs/go-selenium"
+)
+
+// Main is a convenience function to be called from TestMain when RunT or Test
+// are used. It registers -selenium.tags and -selenium.names flags, runs the
+// tests, writes runner.reports for tests run via RunT and Test, stops the
+// client and exits with the tests' exit code.
+func Main(m *testing.M) {
+	selenium.RegisterFlags(flag.CommandLine)
+
+	code := m.Run()
+
+	selenium.EndGoTests(code == 0)
+
+	os.Exit(code)
+}
+
+// RunT executes all tests set via selenium.SetTest as subtests of t. Test
+// failures are reported via t.Error. If runner.parallel_runs is greater than
+// 1, subtests are run in parallel and the number of parallel tests is limited
+// by go test's -parallel flag. Before all and after all hooks are run before
+// and after the subtests. Tests that are not selected by runner.tags,
+// runner.exclude_tags and runner.names are skipped. Set tests are cleared, so
+// that RunT can be called from multiple Go test functions.
+//
+// Unlike selenium.Run, RunT does not stop the client, use Main for that.
+func RunT(t *testing.T) {
+	t.Helper()
+
+	selenium.RunSubtests(
+		t, func(name string, subtest func(t selenium.TestingT)) {
+			t.Run(name, func(t *testing.T) {
+				subtest(t)
+			})
+		},
+	)
+}
+
+// Test executes fn as the given Go test in a new session. Before each and
+// after each hooks are run. Failures are reported via t.Error.
+//
+// Unlike selenium.Run, Test does not stop the client, use Main for that.
+func Test(t *testing.T, fn selenium.TestFunction) {
+	t.Helper()
+
+	selenium.RunGoTest(t, fn)
+}
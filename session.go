@@ -1,9 +1,11 @@
 package selenium
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aleksslitvinovs/go-selenium/selectors"
@@ -15,10 +17,28 @@ import (
 type Session struct {
 	id              string
 	locatorStrategy string
-	// TODO: Maybe create a custom struct for handling error types. Maybe just
-	// an alias to string? Maybe could implement Error interface?
-	errors []string
-	api    *apiClient
+	errors          []*AssertionFailure
+	logs            []logEntry
+	mu              sync.Mutex
+	api             *apiClient
+	downloadDir     string
+	// cancel cancels session's requests, e.g., when the test times out.
+	cancel context.CancelFunc
+	// test and attempt identify the runner's test that uses the session.
+	test    string
+	attempt int
+	// steps are recorded for reports. openSteps are steps that have not
+	// finished yet, the last one is the current step.
+	steps     []*StepResult
+	openSteps []*StepResult
+	// befores and afters are before and after each hooks run as fixtures.
+	befores []*StepResult
+	afters  []*StepResult
+	// artifacts are collected if the test fails.
+	artifacts *Artifacts
+	// checks is the number of conditions that are being checked, see
+	// checking.
+	checks int
 }
 
 // NewSession creates a new session with the capabilities described in config.
@@ -34,9 +54,14 @@ func NewSession() (*Session, error) {
 		)
 	}
 
+	downloadDir, err := createDownloadDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create download directory")
+	}
+
 	req := struct {
 		Capabilities map[string]interface{} `json:"capabilities"`
-	}{getCapabilities()}
+	}{getCapabilities(downloadDir)}
 
 	//nolint:tagliatelle
 	var response struct {
@@ -50,13 +75,27 @@ func NewSession() (*Session, error) {
 		http.MethodPost, "/session", req, &response,
 	)
 	if err != nil {
-		handleError(res, err)
+		removeDownloadDir(downloadDir)
+
+		if errRes := res.getErrorReponse(); errRes != nil {
+			err = errRes
+		}
+
+		return nil, errors.Wrap(err, "failed to create session")
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	s := &Session{
 		id:              response.Value.SessionID,
 		locatorStrategy: config.Element.SelectorType,
-		api:             client.api,
+		api: &apiClient{
+			baseURL:  client.api.baseURL,
+			ctx:      ctx,
+			commands: &commandLog{},
+		},
+		downloadDir: downloadDir,
+		cancel:      cancel,
 	}
 
 	client.ss.mu.Lock()
@@ -74,7 +113,13 @@ func (s *Session) DeleteSession() {
 		fmt.Sprintf("/session/%s", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
+	}
+
+	removeDownloadDir(s.downloadDir)
+
+	if s.cancel != nil {
+		s.cancel()
 	}
 
 	client.ss.mu.Lock()
@@ -83,6 +128,24 @@ func (s *Session) DeleteSession() {
 	client.ss.sessions[s] = false
 }
 
+// isCancelled checks if the session's requests are cancelled.
+func (s *Session) isCancelled() bool {
+	return s.api.ctx != nil && s.api.ctx.Err() != nil
+}
+
+// detached returns a copy of the session whose requests are not cancelled. It
+// is used to clean up the session after it is cancelled.
+func (s *Session) detached() *Session {
+	return &Session{
+		id:              s.id,
+		locatorStrategy: s.locatorStrategy,
+		api:             &apiClient{baseURL: s.api.baseURL},
+		downloadDir:     s.downloadDir,
+		test:            s.test,
+		attempt:         s.attempt,
+	}
+}
+
 // GetID returns the session's ID.
 func (s *Session) GetID() string {
 	return s.id
@@ -90,7 +153,19 @@ func (s *Session) GetID() string {
 
 // AddError adds an error to the session's error list.
 func (s *Session) AddError(err string) {
-	s.errors = append(s.errors, err)
+	s.addFailure(newFailure(err))
+}
+
+// Failures returns the session's failed assertions and errors in the order
+// they occurred.
+func (s *Session) Failures() []*AssertionFailure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	failures := make([]*AssertionFailure, len(s.errors))
+	copy(failures, s.errors)
+
+	return failures
 }
 
 // UseCSS sets session's locator strategy to CSS. All future NewElement calls
@@ -107,21 +182,29 @@ func (s *Session) UseXPath() {
 
 // RaiseErrors raises all the session's errors.
 func (s *Session) RaiseErrors() string {
-	if len(s.errors) == 0 {
+	failures := s.Failures()
+	if len(failures) == 0 {
 		return ""
 	}
 
-	errors := make([]string, 0, len(s.errors))
+	errors := make([]string, 0, len(failures))
 
-	errors = append(errors, s.errors...)
+	for _, f := range failures {
+		errors = append(errors, f.String())
+	}
 
 	return strings.Join(errors, "\n")
 }
 
-func getCapabilities() map[string]interface{} {
-	caps := config.WebDriver.Capabalities
-	if caps == nil {
-		caps = make(map[string]interface{})
+func getCapabilities(downloadDir string) map[string]interface{} {
+	caps := make(map[string]interface{})
+
+	for k, v := range config.WebDriver.Capabalities {
+		caps[k] = v
+	}
+
+	if downloadDir != "" {
+		setDownloadDir(caps, downloadDir)
 	}
 
 	finalCaps := make(map[string]interface{})
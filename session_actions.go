@@ -37,7 +37,7 @@ func (s *Session) OpenURL(url string) *Session {
 		http.MethodPost, fmt.Sprintf("/session/%s/url", s.id), requestBody,
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 
 	return s
@@ -49,13 +49,13 @@ func (s *Session) GetCurrentURL() string {
 		http.MethodGet, fmt.Sprintf("/session/%s/url", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 
 		return ""
 	}
 
 	if res.Value == nil {
-		handleError(nil, errors.New("failed to get current URL"))
+		s.handleError(nil, errors.New("failed to get current URL"))
 
 		return ""
 	}
@@ -73,7 +73,7 @@ func (s *Session) Refresh() *Session {
 		http.MethodPost, fmt.Sprintf("/session/%s/refresh", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 
 	return s
@@ -85,7 +85,7 @@ func (s *Session) Back() *Session {
 		http.MethodPost, fmt.Sprintf("/session/%s/back", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 
 	return s
@@ -97,7 +97,7 @@ func (s *Session) Forward() *Session {
 		http.MethodPost, fmt.Sprintf("/session/%s/forward", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 
 	return s
@@ -109,11 +109,11 @@ func (s *Session) GetTitle() string {
 		http.MethodGet, fmt.Sprintf("/session/%s/title", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 
 	if res.Value == nil {
-		handleError(nil, errors.New("failed to get page title"))
+		s.handleError(nil, errors.New("failed to get page title"))
 	}
 
 	if v, ok := res.Value.(string); ok {
@@ -129,11 +129,11 @@ func (s *Session) GetWindowHandle() string {
 		http.MethodGet, fmt.Sprintf("/session/%s/window", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 
 	if res.Value == nil {
-		handleError(nil, errors.New("failed to get window handle"))
+		s.handleError(nil, errors.New("failed to get window handle"))
 
 		return ""
 	}
@@ -151,11 +151,11 @@ func (s *Session) GetWindowHandles() []string {
 		http.MethodGet, fmt.Sprintf("/session/%s/window/handles", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 
 	if res.Value == nil {
-		handleError(nil, errors.New("failed to get window handles"))
+		s.handleError(nil, errors.New("failed to get window handles"))
 
 		return []string{}
 	}
@@ -188,7 +188,7 @@ func (s *Session) CloseWindow() *Session {
 		http.MethodDelete, fmt.Sprintf("/session/%s/window", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 
 		return s
 	}
@@ -206,7 +206,7 @@ func (s *Session) SwitchHandle(handle string) {
 		http.MethodPost, fmt.Sprintf("/session/%s/window", s.id), payload,
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 }
 
@@ -236,7 +236,7 @@ func (s *Session) newWindowWithType(ht handleType) *Handle {
 		&response,
 	)
 	if err != nil {
-		handleError(
+		s.handleError(
 			res,
 			errors.Wrapf(err, "failed to open new %s", string(ht)),
 		)
@@ -249,11 +249,6 @@ func (s *Session) newWindowWithType(ht handleType) *Handle {
 // the provided element. If nil is provided, the session will switch to the
 // top-level browsing context.
 func (s *Session) SwitchToFrame(e *Element) *Session {
-	//nolint:tagliatelle
-	type id struct {
-		ElementID string `json:"element-6066-11e4-a52e-4f735466cecf"`
-	}
-
 	type payload struct {
 		ID interface{} `json:"id"`
 	}
@@ -263,15 +258,14 @@ func (s *Session) SwitchToFrame(e *Element) *Session {
 	if e == nil {
 		p = payload{nil}
 	} else {
-		e.setElementID()
-		p = payload{id{e.id}}
+		p = payload{e.reference()}
 	}
 
 	res, err := s.api.executeRequest(
 		http.MethodGet, fmt.Sprintf("/session/%s/frame", s.id), p,
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 
 	return s
@@ -284,7 +278,7 @@ func (s *Session) SwitchToParentFrame() *Session {
 		http.MethodPost, fmt.Sprintf("/session/%s/frame/parent", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 	}
 
 	return s
@@ -297,7 +291,7 @@ func (s *Session) TakeScreenshot(name string) *Session {
 	if !strings.HasSuffix(name, ".png") &&
 		!strings.HasSuffix(name, ".jpg") &&
 		!strings.HasSuffix(name, ".jpeg") {
-		handleError(
+		s.handleError(
 			nil,
 			errors.New("screenshot name must end with .png, .jpg or .jpeg"),
 		)
@@ -309,13 +303,13 @@ func (s *Session) TakeScreenshot(name string) *Session {
 		http.MethodGet, fmt.Sprintf("/session/%s/screenshot", s.id),
 	)
 	if err != nil {
-		handleError(res, err)
+		s.handleError(res, err)
 
 		return s
 	}
 
 	if res.Value == nil {
-		handleError(nil, errors.New("failed to take screenshot"))
+		s.handleError(nil, errors.New("failed to take screenshot"))
 
 		return s
 	}
@@ -323,8 +317,15 @@ func (s *Session) TakeScreenshot(name string) *Session {
 	if v, ok := res.Value.(string); ok {
 		err = createScreenshotFile(name, v)
 		if err != nil {
-			handleError(nil, err)
+			s.handleError(nil, err)
+
+			return s
 		}
+
+		s.emit(&Event{
+			Type:       ScreenshotEvent,
+			Screenshot: path.Join(config.ScreenshotDir, name),
+		})
 	}
 
 	return s
@@ -0,0 +1,151 @@
+package selenium
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/types"
+)
+
+// SessionAsserter is a helper struct to assert the page's URL, title, etc.
+// Assertions are retried until they pass or the timeout expires.
+type SessionAsserter struct {
+	s       *Session
+	timeout time.Duration
+}
+
+// ShouldHave returns a SessionAsserter for the given session. The returned
+// SessionAsserter can be used to assert the page's URL, title, cookies, etc.
+func (s *Session) ShouldHave() *SessionAsserter {
+	return &SessionAsserter{
+		s:       s,
+		timeout: config.Element.RetryTimeout.Duration,
+	}
+}
+
+// Within overrides the time during which the assertions are retried. Defaults
+// to element.retry_timeout from the config.
+func (a *SessionAsserter) Within(timeout time.Duration) *SessionAsserter {
+	a.timeout = timeout
+
+	return a
+}
+
+// URL allows asserting the current URL.
+func (a *SessionAsserter) URL() *Valuer {
+	return a.newValuer("page's", "URL", func() (string, error) {
+		return a.s.GetCurrentURL(), nil
+	})
+}
+
+// Title allows asserting the page title.
+func (a *SessionAsserter) Title() *Valuer {
+	return a.newValuer("page's", "title", func() (string, error) {
+		return a.s.GetTitle(), nil
+	})
+}
+
+// PageSource allows asserting the page source.
+func (a *SessionAsserter) PageSource() *Valuer {
+	return a.newValuer("page's", "source", func() (string, error) {
+		return a.s.GetPageSoure(), nil
+	})
+}
+
+// Cookie allows asserting the value of the cookie with the given name. The
+// assertion is retried while the cookie is not set. Negated assertions pass if
+// the cookie is not set.
+func (a *SessionAsserter) Cookie(name string) *Valuer {
+	v := a.newValuer(
+		"page's",
+		fmt.Sprintf("cookie %q", name),
+		func() (string, error) { return a.s.cookieValue(name) },
+	)
+	v.absent = types.ErrNoSuchCookie
+
+	return v
+}
+
+// AlertText allows asserting the text of the currently open alert, confirm or
+// prompt dialog. The assertion is retried while there is no open dialog.
+// Negated assertions pass if there is no open dialog.
+func (a *SessionAsserter) AlertText() *Valuer {
+	v := a.newValuer("alert's", "text", a.s.alertText)
+	v.absent = types.ErrNoSuchAlert
+
+	return v
+}
+
+// WindowCount asserts that the given number of windows and tabs are open.
+func (a *SessionAsserter) WindowCount(count int) {
+	a.newValuer("browser's", "window count", func() (string, error) {
+		return strconv.Itoa(len(a.s.GetWindowHandles())), nil
+	}).EqualTo(strconv.Itoa(count))
+}
+
+func (a *SessionAsserter) newValuer(
+	subject, property string, get func() (string, error),
+) *Valuer {
+	return &Valuer{
+		get:      get,
+		s:        a.s,
+		subject:  subject,
+		property: property,
+		isEqual:  true,
+		timeout:  a.timeout,
+	}
+}
+
+// retryOptions returns options that are used to retry page's assertions.
+func (s *Session) retryOptions(timeout time.Duration) *WaitOptions {
+	return (&WaitOptions{
+		Timeout:       timeout,
+		IgnoredErrors: []error{types.ErrNoSuchAlert, types.ErrNoSuchCookie},
+	}).withDefaults()
+}
+
+// alertText returns the text of the currently open dialog. Unlike GetAlertText,
+// the error is returned instead of being handled.
+func (s *Session) alertText() (string, error) {
+	res, err := s.api.executeRequestVoid(
+		http.MethodGet, fmt.Sprintf("/session/%s/alert/text", s.id),
+	)
+	if err != nil {
+		if errRes := res.getErrorReponse(); errRes != nil {
+			return "", errRes
+		}
+
+		return "", err
+	}
+
+	v, _ := res.Value.(string)
+
+	return v, nil
+}
+
+// cookieValue returns the value of the cookie with the given name. Unlike
+// GetCookie, the error is returned instead of being handled.
+func (s *Session) cookieValue(name string) (string, error) {
+	var response struct {
+		Value Cookie `json:"value"`
+	}
+
+	res, err := s.api.executeRequestCustom(
+		http.MethodGet,
+		fmt.Sprintf("/session/%s/cookie/%s", s.id, url.PathEscape(name)),
+		struct{}{},
+		&response,
+	)
+	if err != nil {
+		if errRes := res.getErrorReponse(); errRes != nil {
+			return "", errRes
+		}
+
+		return "", err
+	}
+
+	return response.Value.Value, nil
+}
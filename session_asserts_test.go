@@ -0,0 +1,130 @@
+package selenium
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSessionAsserterAbsentValues(t *testing.T) {
+	noSuchCookie := fakeResponse{
+		status: http.StatusNotFound,
+		value: map[string]string{
+			"error":   "no such cookie",
+			"message": "cookie not found",
+		},
+	}
+
+	noSuchAlert := fakeResponse{
+		status: http.StatusNotFound,
+		value: map[string]string{
+			"error":   "no such alert",
+			"message": "no alert open",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		route   string
+		res     fakeResponse
+		assert  func(a *SessionAsserter)
+		pass    bool
+		retried bool
+	}{
+		{
+			name:  "cookie",
+			route: "GET /session/fake/cookie/session",
+			res:   fakeResponse{value: map[string]string{"value": "abc"}},
+			assert: func(a *SessionAsserter) {
+				a.Cookie("session").EqualTo("abc")
+			},
+			pass: true,
+		},
+		{
+			name:  "missing cookie",
+			route: "GET /session/fake/cookie/session",
+			res:   noSuchCookie,
+			assert: func(a *SessionAsserter) {
+				a.Cookie("session").EqualTo("abc")
+			},
+			retried: true,
+		},
+		{
+			name:  "missing cookie is not equal",
+			route: "GET /session/fake/cookie/session",
+			res:   noSuchCookie,
+			assert: func(a *SessionAsserter) {
+				a.Cookie("session").Not().EqualTo("abc")
+			},
+			pass: true,
+		},
+		{
+			name:  "cookie is not retrieved",
+			route: "GET /session/fake/cookie/session",
+			res: fakeResponse{
+				status: http.StatusInternalServerError,
+				value: map[string]string{
+					"error":   "unknown error",
+					"message": "browser crashed",
+				},
+			},
+			assert: func(a *SessionAsserter) {
+				a.Cookie("session").Not().EqualTo("abc")
+			},
+		},
+		{
+			name:  "alert text",
+			route: "GET /session/fake/alert/text",
+			res:   fakeResponse{value: "Saved"},
+			assert: func(a *SessionAsserter) {
+				a.AlertText().EqualTo("Saved")
+			},
+			pass: true,
+		},
+		{
+			name:  "missing alert",
+			route: "GET /session/fake/alert/text",
+			res:   noSuchAlert,
+			assert: func(a *SessionAsserter) {
+				a.AlertText().EqualTo("Saved")
+			},
+			retried: true,
+		},
+		{
+			name:  "missing alert is not equal",
+			route: "GET /session/fake/alert/text",
+			res:   noSuchAlert,
+			assert: func(a *SessionAsserter) {
+				a.AlertText().Not().EqualTo("Saved")
+			},
+			pass: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			setTestConfig(t).SoftAsserts = true
+
+			s := newFakeSession(t, map[string]fakeResponse{tt.route: tt.res})
+
+			tt.assert(s.ShouldHave().Within(50 * time.Millisecond))
+
+			failures := s.Failures()
+			if tt.pass && len(failures) != 0 {
+				t.Errorf("unexpected failures %v", failures)
+			}
+
+			if !tt.pass && len(failures) != 1 {
+				t.Errorf("expected 1 failure, got %v", failures)
+			}
+
+			if n := requests(s, tt.route); (n > 1) != tt.retried {
+				t.Errorf(
+					"value is retrieved %d times, retried %t", n, tt.retried,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package selenium
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/logger"
+)
+
+// logEntry is a message logged during the session. Entries are included in
+// test reports.
+type logEntry struct {
+	time    time.Time
+	level   string
+	message string
+}
+
+func (e *logEntry) String() string {
+	return fmt.Sprintf(
+		"%s [%s] %s", e.time.Format("15:04:05.000"), e.level, e.message,
+	)
+}
+
+// infof logs the message and adds it to the session's log.
+func (s *Session) infof(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+
+	logger.Info(msg)
+	s.addLog(logger.InfoLvl, msg)
+}
+
+// errorf logs the message and adds it to the session's log.
+func (s *Session) errorf(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+
+	logger.Error(msg)
+	s.addLog(logger.ErrorLvl, msg)
+}
+
+func (s *Session) addLog(level, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logs = append(s.logs, logEntry{
+		time:    time.Now(),
+		level:   level,
+		message: message,
+	})
+}
+
+// logEntries returns the session's log.
+func (s *Session) logEntries() []logEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]logEntry, len(s.logs))
+	copy(entries, s.logs)
+
+	return entries
+}
@@ -0,0 +1,292 @@
+package selenium
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// stepStartedStatus is used in StepEvent that is sent when the step starts.
+const stepStartedStatus = "started"
+
+// StepResult describes a step of the test. Assertions are recorded as steps
+// too.
+type StepResult struct {
+	Name string
+	// Status is either StatusPassed or StatusFailed.
+	Status   string
+	Start    time.Time
+	Duration time.Duration
+	// Failure is set if the step is a failed assertion or the step is aborted
+	// by an error.
+	Failure *AssertionFailure
+	// Screenshot is the path of the screenshot taken when the step failed.
+	Screenshot string
+	Steps      []*StepResult
+}
+
+// Step runs fn as a named step of the test. Steps can be nested. Each step is
+// timed and shown in the log and reports. The step fails if fn raises an error
+// or any of the step's assertions fail, in that case, a screenshot is taken
+// unless the failed assertion already has one. Failures that occur within the
+// step point to the step.
+//
+//	s.Step("Log in as admin", func() {
+//		s.NewElement("#user").SendKeys("admin")
+//		s.NewElement("#submit").Click()
+//	})
+func (s *Session) Step(name string, fn func()) *Session {
+	step := s.openStep(name, nil)
+	path := s.stepPath()
+
+	s.emit(&Event{Type: StepEvent, Step: path, Status: stepStartedStatus})
+
+	aborted := true
+
+	defer func() {
+		if !aborted {
+			return
+		}
+
+		v := recover()
+
+		var failure *AssertionFailure
+
+		switch f := v.(type) {
+		case nil:
+			// Goroutine exits, e.g., via t.FailNow.
+			s.finishStep(step, path, true, nil)
+
+			return
+		case *AssertionFailure:
+			failure = f
+		default:
+			failure = newFailure(fmt.Sprint(v))
+			failure.Step = path
+
+			s.mu.Lock()
+			step.Failure = failure
+			s.mu.Unlock()
+		}
+
+		s.finishStep(step, path, true, failure)
+
+		panic(failure)
+	}()
+
+	fn()
+
+	aborted = false
+
+	s.finishStep(step, path, false, nil)
+
+	return s
+}
+
+// finishStep closes the step, takes a screenshot if it failed, logs and
+// reports the result. Failure is the raised failure that aborted the step.
+func (s *Session) finishStep(
+	step *StepResult, path string, aborted bool, failure *AssertionFailure,
+) {
+	s.closeStep(step, aborted)
+
+	elapsed := step.Duration
+
+	if step.Status == StatusPassed {
+		s.infof(
+			"Step %q passed (time elapsed %dms)", path, elapsed.Milliseconds(),
+		)
+
+		s.emit(&Event{
+			Type:    StepEvent,
+			Step:    path,
+			Status:  StatusPassed,
+			Elapsed: elapsed.Seconds(),
+		})
+
+		return
+	}
+
+	screenshot := step.screenshot()
+	if screenshot == "" && !s.isCancelled() {
+		screenshot = s.failureScreenshot()
+	}
+
+	s.mu.Lock()
+	step.Screenshot = screenshot
+
+	if failure != nil && failure.Step == path && failure.Screenshot == "" {
+		failure.Screenshot = screenshot
+	}
+	s.mu.Unlock()
+
+	s.errorf(
+		"Step %q failed (time elapsed %dms)", path, elapsed.Milliseconds(),
+	)
+
+	s.emit(&Event{
+		Type:       StepEvent,
+		Step:       path,
+		Status:     StatusFailed,
+		Screenshot: screenshot,
+		Failure:    step.Failure,
+		Elapsed:    elapsed.Seconds(),
+	})
+}
+
+// stepPath returns the names of the open steps joined with " > ". Empty string
+// is returned if no step is open.
+func (s *Session) stepPath() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.openSteps))
+
+	for _, step := range s.openSteps {
+		names = append(names, step.Name)
+	}
+
+	return strings.Join(names, " > ")
+}
+
+// screenshot returns the screenshot of the step or of its nested steps and
+// assertions.
+func (sr *StepResult) screenshot() string {
+	if sr.Screenshot != "" {
+		return sr.Screenshot
+	}
+
+	if sr.Failure != nil && sr.Failure.Screenshot != "" {
+		return sr.Failure.Screenshot
+	}
+
+	for _, step := range sr.Steps {
+		if shot := step.screenshot(); shot != "" {
+			return shot
+		}
+	}
+
+	return ""
+}
+
+// failed reports whether the step or any of its nested steps failed.
+func (sr *StepResult) failed() bool {
+	if sr.Status == StatusFailed {
+		return true
+	}
+
+	for _, step := range sr.Steps {
+		if step.failed() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// openStep starts the step that is added to the given list or, if the list is
+// nil, to the currently open step. Steps that are added until the step is
+// closed are nested in it.
+func (s *Session) openStep(name string, list *[]*StepResult) *StepResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	step := &StepResult{
+		Name:   name,
+		Status: StatusPassed,
+		Start:  time.Now(),
+	}
+
+	if list == nil {
+		list = s.currentSteps()
+	}
+
+	*list = append(*list, step)
+	s.openSteps = append(s.openSteps, step)
+
+	return step
+}
+
+// closeStep finishes the step. The step fails if it is aborted, e.g., by a
+// hard assertion, or any of its nested steps failed.
+func (s *Session) closeStep(step *StepResult, aborted bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := len(s.openSteps) - 1; i >= 0; i-- {
+		if s.openSteps[i] == step {
+			s.openSteps = s.openSteps[:i]
+
+			break
+		}
+	}
+
+	step.Duration = time.Since(step.Start)
+
+	if aborted || step.failed() {
+		step.Status = StatusFailed
+	}
+}
+
+// addStep adds the finished step to the currently open step.
+func (s *Session) addStep(step *StepResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := s.currentSteps()
+	*list = append(*list, step)
+}
+
+// currentSteps returns the list that new steps are added to. s.mu must be
+// held.
+func (s *Session) currentSteps() *[]*StepResult {
+	if len(s.openSteps) == 0 {
+		return &s.steps
+	}
+
+	return &s.openSteps[len(s.openSteps)-1].Steps
+}
+
+// runFixture runs before or after each hook as a step that is added to the
+// given fixtures.
+func (s *Session) runFixture(
+	fixtures *[]*StepResult, name string, fn TestFunction,
+) {
+	step := s.openStep(name, fixtures)
+
+	aborted := true
+
+	defer func() {
+		s.closeStep(step, aborted)
+	}()
+
+	fn(s)
+
+	aborted = false
+}
+
+// stepResults returns copies of the session's steps and fixtures, as steps
+// may still be changed by the test.
+func (s *Session) stepResults() (steps, befores, afters []*StepResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return copySteps(s.steps), copySteps(s.befores), copySteps(s.afters)
+}
+
+func copySteps(steps []*StepResult) []*StepResult {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	c := make([]*StepResult, 0, len(steps))
+
+	for _, step := range steps {
+		sc := *step
+		sc.Steps = copySteps(step.Steps)
+
+		c = append(c, &sc)
+	}
+
+	return c
+}
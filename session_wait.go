@@ -0,0 +1,264 @@
+package selenium
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aleksslitvinovs/go-selenium/types"
+	"github.com/pkg/errors"
+)
+
+// pendingRequestsScript wraps fetch and XMLHttpRequest to count requests that
+// have not finished yet and returns the count. Requests that were started
+// before the script was injected for the first time are not counted.
+const pendingRequestsScript = `function () {
+	if (!window.__goSeleniumRequests) {
+		const requests = { pending: 0 };
+		window.__goSeleniumRequests = requests;
+
+		if (window.fetch) {
+			const fetch = window.fetch;
+
+			window.fetch = function () {
+				requests.pending++;
+
+				return fetch.apply(this, arguments).finally(function () {
+					requests.pending--;
+				});
+			};
+		}
+
+		const send = XMLHttpRequest.prototype.send;
+
+		XMLHttpRequest.prototype.send = function () {
+			requests.pending++;
+			this.addEventListener("loadend", function () {
+				requests.pending--;
+			}, { once: true });
+
+			return send.apply(this, arguments);
+		};
+	}
+
+	return window.__goSeleniumRequests.pending;
+}`
+
+// SessionWaiter is a helper struct to wait for the page to reach a certain
+// state, e.g., URL, title, document's ready state.
+type SessionWaiter struct {
+	s            *Session
+	timeout      time.Duration
+	pollInterval time.Duration
+	message      string
+}
+
+// WaitFor creates an instance of *SessionWaiter with the provided timeout
+// duration.
+func (s *Session) WaitFor(timeout time.Duration) *SessionWaiter {
+	return &SessionWaiter{
+		s:       s,
+		timeout: timeout,
+	}
+}
+
+// WithPollInterval sets the time between condition checks. Defaults to
+// element.poll_interval from the config.
+func (w *SessionWaiter) WithPollInterval(
+	interval time.Duration,
+) *SessionWaiter {
+	w.pollInterval = interval
+
+	return w
+}
+
+// WithMessage sets the error message that is used when the timeout expires.
+func (w *SessionWaiter) WithMessage(message string) *SessionWaiter {
+	w.message = message
+
+	return w
+}
+
+// UntilURLMatches waits until the current URL matches the given regular
+// expression.
+func (w *SessionWaiter) UntilURLMatches(re *regexp.Regexp) *Session {
+	return w.until(
+		fmt.Sprintf("at URL matching %q", re),
+		func() (bool, error) {
+			return re.MatchString(w.s.GetCurrentURL()), nil
+		},
+	)
+}
+
+// UntilURLContains waits until the current URL contains the given string.
+func (w *SessionWaiter) UntilURLContains(url string) *Session {
+	return w.until(
+		fmt.Sprintf("at URL containing %q", url),
+		func() (bool, error) {
+			return strings.Contains(w.s.GetCurrentURL(), url), nil
+		},
+	)
+}
+
+// UntilTitleIs waits until the page title is equal to the given.
+func (w *SessionWaiter) UntilTitleIs(title string) *Session {
+	return w.until(
+		fmt.Sprintf("showing title %q", title),
+		func() (bool, error) { return w.s.GetTitle() == title, nil },
+	)
+}
+
+// UntilTitleContains waits until the page title contains the given string.
+func (w *SessionWaiter) UntilTitleContains(title string) *Session {
+	return w.until(
+		fmt.Sprintf("showing title containing %q", title),
+		func() (bool, error) {
+			return strings.Contains(w.s.GetTitle(), title), nil
+		},
+	)
+}
+
+// UntilDocumentReady waits until the document is fully loaded, i.e.,
+// document.readyState is "complete".
+func (w *SessionWaiter) UntilDocumentReady() *Session {
+	return w.until("ready", func() (bool, error) {
+		return w.s.isDocumentReady(), nil
+	})
+}
+
+// TrackRequests injects the script that tracks fetch and XMLHttpRequest
+// requests, so that UntilNoPendingXHR takes into account requests started by
+// subsequent actions. It should be called after the page is opened and before
+// the action that starts the requests, as navigating to another page removes
+// the script.
+func (s *Session) TrackRequests() *Session {
+	ExecuteScriptInto[int](s, pendingRequestsScript)
+
+	return s
+}
+
+// UntilNoPendingXHR waits until the document is fully loaded and there are no
+// pending fetch and XMLHttpRequest requests. Requests are tracked since
+// TrackRequests is called. If requests are not tracked yet, the tracking
+// starts when the wait starts, therefore, requests started earlier are not
+// taken into account.
+func (w *SessionWaiter) UntilNoPendingXHR() *Session {
+	var idleChecks int
+
+	return w.until("without pending requests", func() (bool, error) {
+		if !w.s.isDocumentReady() {
+			idleChecks = 0
+
+			return false, nil
+		}
+
+		if ExecuteScriptInto[int](w.s, pendingRequestsScript) > 0 {
+			idleChecks = 0
+
+			return false, nil
+		}
+
+		// Requests must not be pending for two consecutive checks, as
+		// requests may be started right after the script is injected.
+		idleChecks++
+
+		return idleChecks > 1, nil
+	})
+}
+
+// UntilAlertPresent waits until an alert, confirm or prompt dialog is open.
+func (w *SessionWaiter) UntilAlertPresent() *Session {
+	return w.until("showing an alert", func() (bool, error) {
+		_, err := w.s.alertText()
+		if errors.Is(err, types.ErrNoSuchAlert) {
+			return false, nil
+		}
+
+		return err == nil, err
+	})
+}
+
+func (w *SessionWaiter) until(
+	conditionName string, condition func() (bool, error),
+) *Session {
+	startTime := time.Now()
+
+	opts := (&WaitOptions{
+		Timeout:      w.timeout,
+		PollInterval: w.pollInterval,
+		Message:      w.message,
+	}).withDefaults()
+
+	err := poll(opts, w.s.checking(condition))
+	if err == nil {
+		w.s.infof(
+			"Page is %s after %s (time elapsed %dms)",
+			conditionName, w.timeout, time.Since(startTime).Milliseconds(),
+		)
+
+		return w.s
+	}
+
+	if !isWaitTimeout(err) {
+		w.s.handleError(nil, err)
+
+		return w.s
+	}
+
+	w.s.handleError(
+		nil,
+		opts.timeoutError(
+			err,
+			"Page is not %s after %s (time elapsed %dms)",
+			conditionName, w.timeout, time.Since(startTime).Milliseconds(),
+		),
+	)
+
+	return w.s
+}
+
+func (s *Session) isDocumentReady() bool {
+	return ExecuteScriptInto[string](
+		s, "function () { return document.readyState }",
+	) == "complete"
+}
+
+// WaitUntil waits until the given condition returns true. The condition is
+// checked based on the provided options, nil can be used to apply the
+// defaults. If the condition returns or raises an error that is not ignored,
+// the wait stops and the error is handled.
+func (s *Session) WaitUntil(
+	condition func(s *Session) (bool, error), opts *WaitOptions,
+) *Session {
+	opts = opts.withDefaults()
+
+	startTime := time.Now()
+
+	err := poll(opts, s.checking(func() (bool, error) { return condition(s) }))
+	if err == nil {
+		s.infof(
+			"Condition is satisfied after %s (time elapsed %dms)",
+			opts.Timeout, time.Since(startTime).Milliseconds(),
+		)
+
+		return s
+	}
+
+	if !isWaitTimeout(err) {
+		s.handleError(nil, errors.Wrap(err, "failed to check condition"))
+
+		return s
+	}
+
+	s.handleError(
+		nil,
+		opts.timeoutError(
+			err,
+			"Condition is not satisfied after %s (time elapsed %dms)",
+			opts.Timeout, time.Since(startTime).Milliseconds(),
+		),
+	)
+
+	return s
+}
@@ -0,0 +1,23 @@
+package selenium
+
+import "testing"
+
+func TestTrackRequests(t *testing.T) {
+	setTestConfig(t).SoftAsserts = true
+
+	s := newFakeSession(t, map[string]fakeResponse{
+		"POST /session/fake/execute/sync": {value: 0},
+	})
+
+	if s.TrackRequests() != s {
+		t.Error("session is not returned")
+	}
+
+	if n := requests(s, "POST /session/fake/execute/sync"); n != 1 {
+		t.Errorf("expected tracker to be injected once, got %d", n)
+	}
+
+	if failures := s.Failures(); len(failures) != 0 {
+		t.Errorf("unexpected failures %v", failures)
+	}
+}
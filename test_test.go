@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/aleksslitvinovs/go-selenium"
+	"github.com/aleksslitvinovs/go-selenium/seleniumtest"
 )
 
 const url = "https://meet.jit.si/RTU_test"
@@ -19,7 +20,7 @@ func Test(t *testing.T) {
 	// selenium.SetTest(JitsiTest1)
 	// selenium.SetTest(JitsiTest2)
 
-	selenium.Run()
+	seleniumtest.RunT(t)
 }
 
 func JitsiTest1(s *selenium.Session) {
@@ -32,11 +33,13 @@ func JitsiTest1(s *selenium.Session) {
 		WaitFor(time.Second * 5).UntilIsVisible().
 		Click()
 
+	s.TrackRequests()
+
 	s.NewElement(".prejoin-input-area input").
 		WaitFor(time.Second * 5).UntilIsVisible().
 		SendKeys("Jitsi 1")
 
-	time.Sleep(10 * time.Second)
+	s.WaitFor(10 * time.Second).UntilNoPendingXHR()
 
 	s.TakeScreenshot("jitsi.png")
 }
@@ -51,11 +54,13 @@ func JitsiTest2(s *selenium.Session) {
 		WaitFor(time.Second * 5).UntilIsVisible().
 		Click()
 
+	s.TrackRequests()
+
 	s.NewElement(".prejoin-input-area input").
 		WaitFor(time.Second * 5).UntilIsVisible().
 		SendKeys("Jitsi 2")
 
-	time.Sleep(10 * time.Second)
+	s.WaitFor(10 * time.Second).UntilNoPendingXHR()
 
 	s.TakeScreenshot("jitsi.png")
 }
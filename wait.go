@@ -0,0 +1,137 @@
+package selenium
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WaitOptions describes how a condition should be awaited.
+type WaitOptions struct {
+	// Timeout is the maximum time to wait for the condition. Defaults to
+	// element.retry_timeout from the config.
+	Timeout time.Duration
+	// PollInterval is the time between condition checks. Defaults to
+	// element.poll_interval from the config.
+	PollInterval time.Duration
+	// IgnoredErrors are errors that do not stop the wait if they are returned
+	// or raised by the condition, e.g., types.ErrStaleElementReference.
+	IgnoredErrors []error
+	// Message is used as the error message when the timeout expires.
+	Message string
+}
+
+// waitTimeoutError is returned by poll when the timeout expires.
+type waitTimeoutError struct {
+	lastErr error
+}
+
+func (e *waitTimeoutError) Error() string {
+	if e.lastErr != nil {
+		return fmt.Sprintf("wait timeout exceeded, last error: %s", e.lastErr)
+	}
+
+	return "wait timeout exceeded"
+}
+
+// isWaitTimeout checks if the error is returned because wait timeout expired.
+func isWaitTimeout(err error) bool {
+	var te *waitTimeoutError
+
+	return errors.As(err, &te)
+}
+
+func (opts *WaitOptions) withDefaults() *WaitOptions {
+	o := WaitOptions{}
+
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.Timeout <= 0 {
+		o.Timeout = config.Element.RetryTimeout.Duration
+	}
+
+	if o.PollInterval <= 0 {
+		o.PollInterval = config.Element.PollInterval.Duration
+	}
+
+	return &o
+}
+
+// poll checks the condition until it returns true or the timeout expires.
+// Ignored errors returned or raised by the condition are skipped. If the
+// timeout expires, *waitTimeoutError is returned.
+func poll(opts *WaitOptions, condition func() (bool, error)) error {
+	endTime := time.Now().Add(opts.Timeout)
+
+	var lastErr error
+
+	for {
+		ok, err := callCondition(condition)
+
+		switch {
+		case err != nil && !isIgnoredError(err, opts.IgnoredErrors):
+			return err
+		case err != nil:
+			lastErr = err
+		case ok:
+			return nil
+		}
+
+		if endTime.Before(time.Now()) {
+			return &waitTimeoutError{lastErr}
+		}
+
+		time.Sleep(opts.PollInterval)
+	}
+}
+
+// timeoutError returns an error that describes the expired wait. Custom
+// message is used if it is set in the options.
+func (opts *WaitOptions) timeoutError(
+	err error, format string, args ...interface{},
+) error {
+	msg := opts.Message
+	if msg == "" {
+		msg = fmt.Sprintf(format, args...)
+	}
+
+	var te *waitTimeoutError
+	if errors.As(err, &te) && te.lastErr != nil {
+		return errors.Errorf("%s (last error: %s)", msg, te.lastErr)
+	}
+
+	return errors.New(msg)
+}
+
+// callCondition calls the condition and converts raised errors, e.g., when
+// hard assertions are used, to returned ones.
+func callCondition(condition func() (bool, error)) (ok bool, err error) {
+	defer func() {
+		v := recover()
+		if v == nil {
+			return
+		}
+
+		switch e := v.(type) {
+		case error:
+			err = e
+		default:
+			err = errors.New(fmt.Sprint(e))
+		}
+	}()
+
+	return condition()
+}
+
+func isIgnoredError(err error, ignored []error) bool {
+	for _, target := range ignored {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}